@@ -0,0 +1,184 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Batch - list of calls sent to the server in one HTTP request (JSON-RPC 2.0 batch)
+type Batch struct {
+	conn  *ClientConnection
+	calls []*BatchCall
+}
+
+// BatchCall - one call of the batch. Its result is available after the batch is sent.
+type BatchCall struct {
+	Method string
+	params interface{}
+	id     int
+	data   []byte
+	err    error
+}
+
+// BatchMethod - method seen by the interceptors for a batch, its params are the calls of the batch ([]*BatchCall).
+// An interceptor may pass only some of the calls to next, the others then fail with ErrCommunicationFailure.
+const BatchMethod = "batch"
+
+var errBatchNotSent = errors.New("batch is not sent")
+
+// NewBatch returns an empty batch of calls for the connection
+func (c *ClientConnection) NewBatch() *Batch {
+	return &Batch{conn: c}
+}
+
+// Add queues the call of method with params, params are the same as the corresponding method sends.
+// Note that SearchQuery in params is sent as is, so all its members must be filled.
+func (b *Batch) Add(method string, params interface{}) *BatchCall {
+	call := &BatchCall{
+		Method: method,
+		params: params,
+		err:    errBatchNotSent,
+	}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Len returns the number of queued calls
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Send sends all queued calls in one request.
+func (b *Batch) Send() error {
+	return b.SendContext(context.Background())
+}
+
+// SendContext sends all queued calls in one request bound to ctx.
+// The returned error means that the whole batch failed, errors of particular calls are reported by BatchCall.
+// If auto re-login is enabled, calls failed because of expired session are sent again after the login.
+func (b *Batch) SendContext(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+	c := b.conn
+	token := c.token()
+	if err := c.doBatch(ctx, b.calls, token); err != nil {
+		return err
+	}
+	var expired []*BatchCall
+	for _, call := range b.calls {
+		if call.err != nil && c.needRelogin(call.Method, call.err) {
+			expired = append(expired, call)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	if err := c.reloginContext(ctx, token); err != nil {
+		return err
+	}
+	return c.doBatch(ctx, expired, c.token())
+}
+
+// doBatch sends calls as one JSON-RPC batch through the interceptors and matches the replies back by id
+func (c *ClientConnection) doBatch(ctx context.Context, calls []*BatchCall, token *string) error {
+	index := make(map[int]*BatchCall, len(calls))
+	for _, call := range calls {
+		call.id = c.Config.getID()
+		call.data, call.err = nil, nil
+		index[call.id] = call
+	}
+	invoker := c.chain(func(ctx context.Context, method string, params interface{}) ([]byte, error) {
+		sent, ok := params.([]*BatchCall)
+		if !ok {
+			return nil, fmt.Errorf("params of %s must be []*BatchCall, got %T", BatchMethod, params)
+		}
+		return c.postBatch(ctx, sent, token)
+	})
+	var replies []json.RawMessage
+	data, err := invoker(ctx, BatchMethod, calls)
+	if err == nil {
+		err = json.Unmarshal(data, &replies)
+	}
+	if err != nil {
+		for _, call := range calls {
+			call.err = err
+		}
+		return err
+	}
+	for _, reply := range replies {
+		head := struct {
+			ID int `json:"id"`
+		}{}
+		if json.Unmarshal(reply, &head) != nil {
+			continue
+		}
+		call, ok := index[head.ID]
+		if !ok {
+			continue
+		}
+		delete(index, head.ID)
+		if call.err = checkError(reply); call.err == nil {
+			call.data = reply
+		}
+	}
+	for _, call := range index {
+		call.err = &ApiError{Code: ErrorCodeCommunicationFailure, Message: "No response to " + call.Method}
+	}
+	return nil
+}
+
+// postBatch performs the round-trip of the batch, it is the last item of the interceptor chain
+func (c *ClientConnection) postBatch(ctx context.Context, calls []*BatchCall, token *string) ([]byte, error) {
+	buffer, err := marshalBatch(calls, token)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	data, err := c.post(ctx, token, buffer)
+	if err == nil {
+		err = checkError(data)
+	}
+	c.logCall(ctx, CallRecord{
+		Method:       BatchMethod,
+		Duration:     time.Since(start),
+		ResponseSize: len(data),
+		Err:          err,
+	}, token, buffer)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Result returns the raw response of the call, the same as CallRaw returns
+func (call *BatchCall) Result() ([]byte, error) {
+	return call.data, call.err
+}
+
+// Params returns the params of the call
+func (call *BatchCall) Params() interface{} {
+	return call.params
+}
+
+// Err returns the error of the call
+func (call *BatchCall) Err() error {
+	return call.err
+}
+
+// Decode unmarshals the "result" member of the response into v
+func (call *BatchCall) Decode(v interface{}) error {
+	if call.err != nil {
+		return call.err
+	}
+	result := struct {
+		Result json.RawMessage `json:"result"`
+	}{}
+	if err := json.Unmarshal(call.data, &result); err != nil {
+		return err
+	}
+	return json.Unmarshal(result.Result, v)
+}
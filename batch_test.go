@@ -0,0 +1,157 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatch_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var calls []parameters
+		if err := json.NewDecoder(r.Body).Decode(&calls); err != nil {
+			t.Error(err)
+			return
+		}
+		replies := make([]interface{}, 0, len(calls))
+		// answer in reverse order to check matching by id
+		for i := len(calls) - 1; i >= 0; i-- {
+			switch calls[i].Method {
+			case "Session.whoAmI":
+				replies = append(replies, map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      calls[i].ID,
+					"result":  map[string]interface{}{"userDetails": map[string]string{"loginName": "user"}},
+				})
+			case "Folders.get":
+				replies = append(replies, map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      calls[i].ID,
+					"error":   map[string]interface{}{"code": ErrorCodeNoSuchFolder, "message": "No such folder"},
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(replies)
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := conn.NewBatch()
+	whoAmI := batch.Add("Session.whoAmI", nil)
+	folders := batch.Add("Folders.get", nil)
+	missed := batch.Add("Mails.get", nil)
+	if err = batch.Send(); err != nil {
+		t.Fatal(err)
+	}
+	user := struct {
+		UserDetails UserInfo `json:"userDetails"`
+	}{}
+	if err = whoAmI.Decode(&user); err != nil || user.UserDetails.LoginName != "user" {
+		t.Errorf("unexpected result %+v, %v", user, err)
+	}
+	if !errors.Is(folders.Err(), ErrNoSuchFolder) {
+		t.Errorf("expected ErrNoSuchFolder, got %v", folders.Err())
+	}
+	if !errors.Is(missed.Err(), ErrCommunicationFailure) {
+		t.Errorf("expected ErrCommunicationFailure, got %v", missed.Err())
+	}
+}
+
+func TestBatch_Intercepted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Audit") != "1" {
+			t.Error("header is not injected")
+		}
+		_, _ = w.Write([]byte(`[{"jsonrpc":"2.0","id":1,"result":{}}]`))
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var methods []string
+	conn.Use(func(ctx context.Context, method string, params interface{}, next Invoker) ([]byte, error) {
+		methods = append(methods, method)
+		if calls, ok := params.([]*BatchCall); ok {
+			for _, call := range calls {
+				methods = append(methods, call.Method)
+			}
+		}
+		return next(WithRequestHeader(ctx, "X-Audit", "1"), method, params)
+	})
+	batch := conn.NewBatch()
+	call := batch.Add("Session.whoAmI", nil)
+	if err = batch.Send(); err != nil || call.Err() != nil {
+		t.Fatal(err, call.Err())
+	}
+	if fmt.Sprint(methods) != "[batch Session.whoAmI]" {
+		t.Errorf("unexpected intercepted calls %v", methods)
+	}
+}
+
+func TestBatch_Failed(t *testing.T) {
+	errFailed := errors.New("failed")
+	conf := &Config{url: "http://localhost/webmail/api/jsonrpc/", transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errFailed
+	})}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := conn.NewBatch()
+	call := batch.Add("Session.whoAmI", nil)
+	if err = batch.Send(); !errors.Is(err, errFailed) {
+		t.Errorf("got %v", err)
+	}
+	if data, err := call.Result(); data != nil || !errors.Is(err, errFailed) {
+		t.Errorf("got %s, %v", data, err)
+	}
+}
+
+func TestBatch_InterceptedParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var calls []parameters
+		_ = json.NewDecoder(r.Body).Decode(&calls)
+		if len(calls) != 1 || calls[0].Method != "Session.whoAmI" {
+			t.Errorf("unexpected calls %+v", calls)
+		}
+		_, _ = fmt.Fprintf(w, `[{"jsonrpc":"2.0","id":%d,"result":{}}]`, calls[0].ID)
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// drops the calls of Folders.get
+	conn.Use(func(ctx context.Context, method string, params interface{}, next Invoker) ([]byte, error) {
+		var kept []*BatchCall
+		for _, call := range params.([]*BatchCall) {
+			if call.Method != "Folders.get" {
+				kept = append(kept, call)
+			}
+		}
+		return next(ctx, method, kept)
+	})
+	batch := conn.NewBatch()
+	whoAmI := batch.Add("Session.whoAmI", nil)
+	folders := batch.Add("Folders.get", nil)
+	if err = batch.Send(); err != nil || whoAmI.Err() != nil || !errors.Is(folders.Err(), ErrCommunicationFailure) {
+		t.Errorf("got %v, %v, %v", err, whoAmI.Err(), folders.Err())
+	}
+}
+
+// roundTripFunc - adapter to use a function as http.RoundTripper
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
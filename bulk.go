@@ -0,0 +1,261 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// DefaultChunkSize - number of items sent by one call of a bulk operation unless WithChunkSize is used
+const DefaultChunkSize = 500
+
+// BulkOption - setting of a bulk operation
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	chunkSize   int
+	concurrency int
+}
+
+// WithChunkSize sets the number of items sent by one call
+func WithChunkSize(size int) BulkOption {
+	return func(o *bulkOptions) {
+		if size > 0 {
+			o.chunkSize = size
+		}
+	}
+}
+
+// WithConcurrency sets the number of calls running at the same time, the chunks are sent one by one by default
+func WithConcurrency(n int) BulkOption {
+	return func(o *bulkOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// BulkResult - merged report of the calls of a bulk operation.
+// InputIndex of the errors and results is the index of the item in the input of the bulk operation.
+type BulkResult struct {
+	Errors  ErrorList        // errors of particular items
+	Created CreateResultList // results of copy and move
+	Set     SetResultList    // results of set
+	Done    int              // number of items sent in the successful calls
+}
+
+// MailsGetIds returns the ids of all mails found by the query, e.g. as the input of a bulk operation.
+//	folderIds - list of global identifiers of folders to be searched
+//	query - query attributes, Start and Limit limit all the found mails
+func (c *ClientConnection) MailsGetIds(folderIds KIdList, query SearchQuery) (KIdList, error) {
+	return c.MailsGetIdsContext(context.Background(), folderIds, query)
+}
+
+// MailsGetIdsContext - the same as MailsGetIds, but the calls are bound to ctx.
+func (c *ClientConnection) MailsGetIdsContext(ctx context.Context, folderIds KIdList, query SearchQuery) (KIdList, error) {
+	query.Fields = StringList{string(MailFieldId)}
+	var ids KIdList
+	it := c.MailsGetIteratorContext(ctx, folderIds, query, WithPageSize(DefaultChunkSize))
+	for it.Next() {
+		ids = append(ids, it.Item().Id)
+	}
+	return ids, it.Err()
+}
+
+// MailsBulkSet sets the same fields of all mails, e.g. MailFieldIsSeen to true, the other fields are not changed.
+// The ids are split into chunks sent by separate calls of Mails.set.
+//	ids - global identifiers of the mails
+//	fields - values of the fields to be set
+//	options - chunk size and concurrency
+// Return
+//	result - merged errors and results of the items, the items of failed calls are not reported
+//	err - error of the first failed call, the next chunks are not sent then, the ones already sent are reported
+func (c *ClientConnection) MailsBulkSet(ids KIdList, fields map[MailField]interface{}, options ...BulkOption) (*BulkResult, error) {
+	return c.MailsBulkSetContext(context.Background(), ids, fields, options...)
+}
+
+// MailsBulkSetContext - the same as MailsBulkSet, but the calls are bound to ctx.
+func (c *ClientConnection) MailsBulkSetContext(ctx context.Context, ids KIdList, fields map[MailField]interface{}, options ...BulkOption) (*BulkResult, error) {
+	return runBulk(ctx, len(ids), options, func(ctx context.Context, start, end int) (*BulkResult, error) {
+		mails := make([]map[string]interface{}, 0, end-start)
+		for _, id := range ids[start:end] {
+			mail := map[string]interface{}{"id": id}
+			for field, value := range fields {
+				mail[string(field)] = value
+			}
+			mails = append(mails, mail)
+		}
+		params := struct {
+			Mails []map[string]interface{} `json:"mails"`
+		}{mails}
+		data, err := c.CallRawContext(ctx, "Mails.set", params)
+		if err != nil {
+			return nil, err
+		}
+		result := struct {
+			Result struct {
+				Errors ErrorList     `json:"errors"`
+				Result SetResultList `json:"result"`
+			} `json:"result"`
+		}{}
+		if err = json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		return &BulkResult{Errors: result.Result.Errors, Set: result.Result.Result}, nil
+	})
+}
+
+// MailsBulkRemove removes the mails, the ids are split into chunks sent by separate calls of Mails.remove.
+//	ids - global identifiers of the mails
+//	options - chunk size and concurrency
+// Return
+//	result - merged errors of the items, the items of failed calls are not reported
+//	err - error of the first failed call, the next chunks are not sent then, the ones already sent are reported
+func (c *ClientConnection) MailsBulkRemove(ids KIdList, options ...BulkOption) (*BulkResult, error) {
+	return c.MailsBulkRemoveContext(context.Background(), ids, options...)
+}
+
+// MailsBulkRemoveContext - the same as MailsBulkRemove, but the calls are bound to ctx.
+func (c *ClientConnection) MailsBulkRemoveContext(ctx context.Context, ids KIdList, options ...BulkOption) (*BulkResult, error) {
+	return runBulk(ctx, len(ids), options, func(ctx context.Context, start, end int) (*BulkResult, error) {
+		errs, err := c.MailsRemoveContext(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		return &BulkResult{Errors: errs}, nil
+	})
+}
+
+// MailsBulkMove moves the mails to the folder, the ids are split into chunks sent by separate calls of Mails.move.
+//	ids - global identifiers of the mails
+//	folder - global identifier of the target folder
+//	options - chunk size and concurrency
+// Return
+//	result - merged errors and new ids of the items, the items of failed calls are not reported
+//	err - error of the first failed call, the next chunks are not sent then, the ones already sent are reported
+func (c *ClientConnection) MailsBulkMove(ids KIdList, folder KId, options ...BulkOption) (*BulkResult, error) {
+	return c.MailsBulkMoveContext(context.Background(), ids, folder, options...)
+}
+
+// MailsBulkMoveContext - the same as MailsBulkMove, but the calls are bound to ctx.
+func (c *ClientConnection) MailsBulkMoveContext(ctx context.Context, ids KIdList, folder KId, options ...BulkOption) (*BulkResult, error) {
+	return runBulk(ctx, len(ids), options, func(ctx context.Context, start, end int) (*BulkResult, error) {
+		errs, created, err := c.MailsMoveContext(ctx, ids[start:end], folder)
+		if err != nil {
+			return nil, err
+		}
+		return &BulkResult{Errors: errs, Created: created}, nil
+	})
+}
+
+// MailsBulkCopy copies the mails to the folder, the ids are split into chunks sent by separate calls of Mails.copy.
+//	ids - global identifiers of the mails
+//	folder - global identifier of the target folder
+//	options - chunk size and concurrency
+// Return
+//	result - merged errors and ids of the copies, the items of failed calls are not reported
+//	err - error of the first failed call, the next chunks are not sent then, the ones already sent are reported
+func (c *ClientConnection) MailsBulkCopy(ids KIdList, folder KId, options ...BulkOption) (*BulkResult, error) {
+	return c.MailsBulkCopyContext(context.Background(), ids, folder, options...)
+}
+
+// MailsBulkCopyContext - the same as MailsBulkCopy, but the calls are bound to ctx.
+func (c *ClientConnection) MailsBulkCopyContext(ctx context.Context, ids KIdList, folder KId, options ...BulkOption) (*BulkResult, error) {
+	return runBulk(ctx, len(ids), options, func(ctx context.Context, start, end int) (*BulkResult, error) {
+		errs, created, err := c.MailsCopyContext(ctx, ids[start:end], folder)
+		if err != nil {
+			return nil, err
+		}
+		return &BulkResult{Errors: errs, Created: created}, nil
+	})
+}
+
+// bulkCall processes the input items from start to end, the input indexes of its result are relative to start
+type bulkCall func(ctx context.Context, start, end int) (*BulkResult, error)
+
+// runBulk splits n input items into chunks processed by call and merges the results in the order of the items.
+// After the first failed call the next chunks are not started, the calls already running are finished
+// and their results are merged.
+func runBulk(ctx context.Context, n int, options []BulkOption, call bulkCall) (*BulkResult, error) {
+	o := bulkOptions{chunkSize: DefaultChunkSize, concurrency: 1}
+	for _, option := range options {
+		option(&o)
+	}
+	chunks := (n + o.chunkSize - 1) / o.chunkSize
+	bounds := func(i int) (int, int) {
+		start, end := i*o.chunkSize, (i+1)*o.chunkSize
+		if end > n {
+			end = n
+		}
+		return start, end
+	}
+	results := make([]*BulkResult, chunks)
+	var mu sync.Mutex
+	var firstErr error
+	stop := make(chan struct{}) // closed after the first failure
+	var stopOnce sync.Once
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < o.concurrency && w < chunks; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				select {
+				case <-stop:
+					continue
+				default:
+				}
+				start, end := bounds(i)
+				result, err := call(ctx, start, end)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					stopOnce.Do(func() { close(stop) })
+					continue
+				}
+				results[i] = result
+			}
+		}()
+	}
+feed:
+	for i := 0; i < chunks; i++ {
+		select {
+		case next <- i:
+		case <-stop:
+			break feed
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+	merged := &BulkResult{}
+	for i, result := range results {
+		if result == nil {
+			if firstErr == nil {
+				// canceled by the caller before the chunk was sent
+				firstErr = ctx.Err()
+			}
+			continue
+		}
+		start, end := bounds(i)
+		merged.Done += end - start
+		for _, e := range result.Errors {
+			e.InputIndex += start
+			merged.Errors = append(merged.Errors, e)
+		}
+		for _, r := range result.Created {
+			r.InputIndex += start
+			merged.Created = append(merged.Created, r)
+		}
+		for _, r := range result.Set {
+			r.InputIndex += start
+			merged.Set = append(merged.Set, r)
+		}
+	}
+	return merged, firstErr
+}
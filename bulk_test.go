@@ -0,0 +1,37 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRunBulk_FailureFinishesRunningCalls(t *testing.T) {
+	errFailed := errors.New("failed")
+	running := make(chan struct{})
+	failed := make(chan struct{})
+	result, err := runBulk(context.Background(), 8, []BulkOption{WithChunkSize(2), WithConcurrency(2)},
+		func(ctx context.Context, start, end int) (*BulkResult, error) {
+			switch start {
+			case 0:
+				<-running
+				close(failed)
+				return nil, errFailed
+			case 2:
+				close(running)
+				<-failed
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return &BulkResult{Errors: ErrorList{{InputIndex: 1}}}, nil
+			}
+			return &BulkResult{}, nil
+		})
+	if !errors.Is(err, errFailed) {
+		t.Errorf("got %v", err)
+	}
+	// the chunk running at the time of the failure is finished, the later ones may be started before it is noticed
+	if result.Done < 2 || len(result.Errors) != 1 || result.Errors[0].InputIndex != 3 {
+		t.Errorf("got %+v", result)
+	}
+}
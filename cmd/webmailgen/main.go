@@ -0,0 +1,324 @@
+// Command webmailgen generates the API methods of webmail.ClientConnection
+// from the schema of Kerio Connect interfaces.
+//
+// Each file schema/NAME.json describes the methods of one interface and produces NAMEGen.go
+// with a method, its Context variant and the type of its result (e.g. MailsGetResult) for every entry. The types used by the schema
+// are declared by hand in the package. Run it from the package directory:
+//
+//	go generate
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const suffix = "Gen.go"
+
+// Schema - methods of one Kerio interface
+type Schema struct {
+	Doc     []string `json:"doc,omitempty"` // description of the interface
+	Methods []Method `json:"methods"`
+}
+
+// Method - one method of the interface
+type Method struct {
+	Method  string   `json:"method"`            // JSON-RPC method, e.g. "Mails.get"
+	Name    string   `json:"name,omitempty"`    // Go name of the method, e.g. "MailsGet"; derived from Method if empty
+	Doc     []string `json:"doc,omitempty"`     // description
+	Params  []Value  `json:"params,omitempty"`  // members of "params"
+	Results []Value  `json:"results,omitempty"` // members of "result" returned by the method
+}
+
+// Value - parameter or result of the method
+type Value struct {
+	Name    string `json:"name"`              // JSON name, also used as the name of Go parameter
+	Type    string `json:"type"`              // Go type
+	Doc     string `json:"doc,omitempty"`     // description
+	Pointer bool   `json:"pointer,omitempty"` // result is returned as pointer
+}
+
+func main() {
+	schemaDir := flag.String("schema", "schema", "directory with schema files")
+	outDir := flag.String("out", ".", "directory of the package")
+	flag.Parse()
+	types, err := parseTypes(*outDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(*schemaDir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, file := range files {
+		if err = generate(file, *outDir, types); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// parseTypes returns the type declarations of the package, except the generated files
+func parseTypes(dir string) (map[string]ast.Expr, error) {
+	filter := func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, suffix) && !strings.HasSuffix(name, "_test.go")
+	}
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	types := map[string]ast.Expr{}
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					spec := spec.(*ast.TypeSpec)
+					types[spec.Name.Name] = spec.Type
+				}
+			}
+		}
+	}
+	return types, nil
+}
+
+func generate(file, outDir string, types map[string]ast.Expr) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	schema := Schema{}
+	if err = json.Unmarshal(data, &schema); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	base := strings.TrimSuffix(filepath.Base(file), ".json")
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by webmailgen from schema/%s.json. DO NOT EDIT.\n\n", base)
+	fmt.Fprintf(buf, "package webmail\n\n")
+	imports := []string{"context"}
+	for _, method := range schema.Methods {
+		if len(method.Results) > 0 {
+			imports = append(imports, "encoding/json")
+			break
+		}
+	}
+	fmt.Fprintf(buf, "import (\n")
+	for _, path := range imports {
+		fmt.Fprintf(buf, "\t%q\n", path)
+	}
+	fmt.Fprintf(buf, ")\n")
+	if len(schema.Doc) > 0 {
+		fmt.Fprintf(buf, "\n")
+		for _, line := range schema.Doc {
+			fmt.Fprintf(buf, "// %s\n", line)
+		}
+	}
+	for _, method := range schema.Methods {
+		if err = writeMethod(buf, method, types); err != nil {
+			return fmt.Errorf("%s: %s: %w", file, method.Method, err)
+		}
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w\n%s", file, err, buf.Bytes())
+	}
+	return ioutil.WriteFile(filepath.Join(outDir, base+suffix), source, 0644)
+}
+
+// goName returns the name of the method, e.g. "MailsGet" for "Mails.get"
+func goName(method Method) string {
+	if method.Name != "" {
+		return method.Name
+	}
+	parts := strings.SplitN(method.Method, ".", 2)
+	return upperFirst(parts[0]) + upperFirst(parts[len(parts)-1])
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func writeMethod(buf *bytes.Buffer, method Method, types map[string]ast.Expr) error {
+	name := goName(method)
+	var args, names, returns, zeros []string
+	for _, param := range method.Params {
+		args = append(args, param.Name+" "+param.Type)
+		names = append(names, param.Name)
+	}
+	for _, result := range method.Results {
+		if result.Pointer {
+			returns = append(returns, "*"+result.Type)
+			zeros = append(zeros, "nil")
+			continue
+		}
+		zero, err := zeroValue(result.Type, types)
+		if err != nil {
+			return err
+		}
+		returns = append(returns, result.Type)
+		zeros = append(zeros, zero)
+	}
+	returns = append(returns, "error")
+	zeros = append(zeros, "err")
+	signature := strings.Join(returns, ", ")
+	if len(returns) > 1 {
+		signature = "(" + signature + ")"
+	}
+
+	resultType := name + "Result"
+	if len(method.Results) > 0 {
+		writeResultType(buf, resultType, method)
+	}
+
+	// method without context
+	fmt.Fprintf(buf, "\n")
+	writeDoc(buf, name, method)
+	fmt.Fprintf(buf, "func (c *ClientConnection) %s(%s) %s {\n", name, strings.Join(args, ", "), signature)
+	call := fmt.Sprintf("c.%sContext(%s)", name, strings.Join(append([]string{"context.Background()"}, names...), ", "))
+	fmt.Fprintf(buf, "\treturn %s\n}\n", call)
+
+	// method with context
+	fmt.Fprintf(buf, "\n// %sContext - the same as %s, but the call is bound to ctx.\n", name, name)
+	fmt.Fprintf(buf, "func (c *ClientConnection) %sContext(%s) %s {\n",
+		name, strings.Join(append([]string{"ctx context.Context"}, args...), ", "), signature)
+	for _, param := range method.Params {
+		if param.Type == "SearchQuery" {
+			fmt.Fprintf(buf, "\t%s = addMissedParametersToSearchQuery(%s)\n", param.Name, param.Name)
+		}
+	}
+	params := "nil"
+	if len(method.Params) > 0 {
+		params = "params"
+		fmt.Fprintf(buf, "\tparams := struct {\n")
+		for _, param := range method.Params {
+			fmt.Fprintf(buf, "\t\t%s %s `json:%q`\n", upperFirst(param.Name), param.Type, param.Name)
+		}
+		fmt.Fprintf(buf, "\t}{%s}\n", strings.Join(names, ", "))
+	}
+	if len(method.Results) == 0 {
+		fmt.Fprintf(buf, "\t_, err := c.CallRawContext(ctx, %q, %s)\n\treturn err\n}\n", method.Method, params)
+		return nil
+	}
+	fmt.Fprintf(buf, "\tdata, err := c.CallRawContext(ctx, %q, %s)\n", method.Method, params)
+	fmt.Fprintf(buf, "\tif err != nil {\n\t\treturn %s\n\t}\n", strings.Join(zeros, ", "))
+	fmt.Fprintf(buf, "\tresult := struct {\n\t\tResult %s `json:\"result\"`\n\t}{}\n", resultType)
+	var values []string
+	for _, result := range method.Results {
+		value := "result.Result." + upperFirst(result.Name)
+		if result.Pointer {
+			value = "&" + value
+		}
+		values = append(values, value)
+	}
+	fmt.Fprintf(buf, "\terr = json.Unmarshal(data, &result)\n")
+	fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(append(values, "err"), ", "))
+	return nil
+}
+
+// writeResultType writes the struct of the members of "result" returned by the method
+func writeResultType(buf *bytes.Buffer, name string, method Method) {
+	fmt.Fprintf(buf, "\n// %s - result of %s\n", name, method.Method)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, result := range method.Results {
+		fmt.Fprintf(buf, "\t%s %s `json:%q`", upperFirst(result.Name), result.Type, result.Name)
+		if result.Doc != "" {
+			fmt.Fprintf(buf, " // %s", result.Doc)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+// writeDoc writes the doc comment in the format used by the package:
+//
+//	// Name - description
+//	//	param - description
+//	// Return
+//	//	result - description
+func writeDoc(buf *bytes.Buffer, name string, method Method) {
+	doc := method.Doc
+	if len(doc) == 0 {
+		doc = []string{""}
+	}
+	fmt.Fprintf(buf, "%s\n", strings.TrimRight(fmt.Sprintf("// %s - %s", name, doc[0]), " -"))
+	for _, line := range doc[1:] {
+		fmt.Fprintf(buf, "// %s\n", line)
+	}
+	writeValues(buf, method.Params)
+	if len(method.Results) > 0 {
+		fmt.Fprintf(buf, "// Return\n")
+		writeValues(buf, method.Results)
+	}
+}
+
+func writeValues(buf *bytes.Buffer, values []Value) {
+	for _, value := range values {
+		if value.Doc == "" {
+			fmt.Fprintf(buf, "//\t%s\n", value.Name)
+		} else {
+			fmt.Fprintf(buf, "//\t%s - %s\n", value.Name, value.Doc)
+		}
+	}
+}
+
+// zeroValue returns the zero value literal of the type
+func zeroValue(typ string, types map[string]ast.Expr) (string, error) {
+	switch typ {
+	case "string":
+		return `""`, nil
+	case "bool":
+		return "false", nil
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "byte", "rune":
+		return "0", nil
+	case "interface{}", "error":
+		return "nil", nil
+	}
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") {
+		return "nil", nil
+	}
+	expr, ok := types[typ]
+	if !ok {
+		return "", fmt.Errorf("unknown type %s, declared types: %s", typ, strings.Join(typeNames(types), ", "))
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		zero, err := zeroValue(expr.Name, types)
+		if err != nil {
+			return "", err
+		}
+		if zero == "nil" {
+			return zero, nil
+		}
+		return typ + "(" + zero + ")", nil
+	case *ast.StructType:
+		return typ + "{}", nil
+	}
+	return "nil", nil
+}
+
+func typeNames(types map[string]ast.Expr) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		method Method
+		want   string
+	}{
+		{Method{Method: "Mails.get"}, "MailsGet"},
+		{Method{Method: "Session.logout", Name: "Logout"}, "Logout"},
+		{Method{Method: "callManager.dial"}, "CallManagerDial"},
+	}
+	for _, test := range tests {
+		if got := goName(test.method); got != test.want {
+			t.Errorf("goName(%q) = %q, want %q", test.method.Method, got, test.want)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	types, err := parseTypes("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"int":         "0",
+		"string":      `""`,
+		"*Mail":       "nil",
+		"MailList":    "nil",
+		"KId":         `KId("")`,
+		"Mail":        "Mail{}",
+		"UtcDateTime": `UtcDateTime("")`,
+	}
+	for typ, want := range tests {
+		got, err := zeroValue(typ, types)
+		if err != nil {
+			t.Errorf("zeroValue(%q) error: %v", typ, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("zeroValue(%q) = %s, want %s", typ, got, want)
+		}
+	}
+	if _, err = zeroValue("Unknown", types); err == nil {
+		t.Error("zeroValue of unknown type must fail")
+	}
+}
+
+func TestWriteMethod_ResultType(t *testing.T) {
+	types, err := parseTypes("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := Method{
+		Method: "Mails.get",
+		Params: []Value{{Name: "folderIds", Type: "KIdList"}},
+		Results: []Value{
+			{Name: "list", Type: "MailList", Doc: "all found e-mails"},
+			{Name: "totalItems", Type: "int"},
+			{Name: "mail", Type: "Mail", Pointer: true},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err = writeMethod(buf, method, types); err != nil {
+		t.Fatal(err)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("%v\n%s", err, buf.Bytes())
+	}
+	for _, want := range []string{
+		"type MailsGetResult struct {\n" +
+			"\tList       MailList `json:\"list\"` // all found e-mails\n" +
+			"\tTotalItems int      `json:\"totalItems\"`\n" +
+			"\tMail       Mail     `json:\"mail\"`\n}",
+		"func (c *ClientConnection) MailsGet(folderIds KIdList) (MailList, int, *Mail, error) {",
+		"Result MailsGetResult `json:\"result\"`",
+		"return result.Result.List, result.Result.TotalItems, &result.Result.Mail, err",
+	} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("missing %q in\n%s", want, source)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"strings"
+	"time"
+)
+
+// Prefixes of the subjects of replies and forwards
+const (
+	ReplyPrefix   = "Re: "
+	ForwardPrefix = "Fwd: "
+)
+
+// Composer - builder of a new mail, a reply or a forward, which is then sent or saved as draft.
+// It is created by Compose, Reply, ReplyAll or Forward of ClientConnection.
+type Composer struct {
+	Mail Mail   // recipients, subject and attachments; DisplayableParts are built from Text, HTML and the quote
+	Text string // plain text written above the quote
+	HTML string // HTML written above the quote, the mail has no HTML part if it is empty and nothing HTML is quoted
+
+	conn       *ClientConnection
+	original   *Mail
+	quoteText  string // quoted plain text of the original
+	quoteHTML  string // quoted HTML of the original
+	flag       string // flag of the original set after sending, "isAnswered" or "isForwarded"
+	draftsId   KId
+	draftsDone bool
+}
+
+// Compose returns the composer of a new mail
+func (c *ClientConnection) Compose() *Composer {
+	return &Composer{conn: c}
+}
+
+// Reply returns the composer of the reply to the sender of original (or to its Reply-To addresses).
+// The original must be read with its headers and displayable parts, which are quoted.
+func (c *ClientConnection) Reply(original Mail) *Composer {
+	composer := c.newReply(original)
+	composer.Mail.To = replyRecipients(original)
+	return composer
+}
+
+// ReplyAll returns the composer of the reply to the sender and all recipients of original,
+// the addresses of the current user from SessionWhoAmI are removed.
+func (c *ClientConnection) ReplyAll(original Mail) (*Composer, error) {
+	return c.ReplyAllContext(context.Background(), original)
+}
+
+// ReplyAllContext - the same as ReplyAll, but the call is bound to ctx.
+func (c *ClientConnection) ReplyAllContext(ctx context.Context, original Mail) (*Composer, error) {
+	user, err := c.SessionWhoAmIContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	own := map[string]bool{}
+	for _, address := range append(user.Emails, user.LoginName, user.PreferredAddress, user.ReplyToAddress) {
+		own[strings.ToLower(address)] = true
+	}
+	seen := map[string]bool{}
+	filter := func(list EMailList) EMailList {
+		var result EMailList
+		for _, email := range list {
+			address := strings.ToLower(email.Address)
+			if address == "" || own[address] || seen[address] {
+				continue
+			}
+			seen[address] = true
+			result = append(result, email)
+		}
+		return result
+	}
+	composer := c.newReply(original)
+	composer.Mail.To = filter(append(replyRecipients(original), original.To...))
+	composer.Mail.Cc = filter(original.Cc)
+	if len(composer.Mail.To) == 0 {
+		composer.Mail.To, composer.Mail.Cc = composer.Mail.Cc, nil
+	}
+	return composer, nil
+}
+
+// Forward returns the composer of the forward of original with its attachments.
+// The original must be read with its headers and displayable parts, which are quoted.
+func (c *ClientConnection) Forward(original Mail) *Composer {
+	composer := &Composer{conn: c, original: &original, flag: "isForwarded"}
+	composer.Mail.Subject = prefixSubject(ForwardPrefix, original.Subject)
+	if id := messageID(original); id != "" {
+		composer.Mail.Headers = MimeHeaderList{{Type: MhResentMessageID, Value: id}}
+	}
+	for _, attachment := range original.Attachments {
+		composer.Mail.Attachments = append(composer.Mail.Attachments, Attachment{
+			Id:          attachment.Id,
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			ContentId:   attachment.ContentId,
+		})
+	}
+	header := []string{
+		"---------- Forwarded message ----------",
+		"From: " + formatAddresses(EMailList{original.From}),
+		"Date: " + formatDate(original),
+		"Subject: " + original.Subject,
+		"To: " + formatAddresses(original.To),
+	}
+	if len(original.Cc) > 0 {
+		header = append(header, "Cc: "+formatAddresses(original.Cc))
+	}
+	if text, ok := plainContent(original); ok {
+		composer.quoteText = strings.Join(header, "\n") + "\n\n" + text
+	}
+	if content, ok := partContent(original, CtTextHtml); ok {
+		escaped := make([]string, len(header))
+		for i, line := range header {
+			escaped[i] = html.EscapeString(line)
+		}
+		composer.quoteHTML = "<div>" + strings.Join(escaped, "<br>") + "</div><br>" + content
+	}
+	return composer
+}
+
+// Send sends the mail. After sending a reply or a forward, the original is marked as answered or forwarded.
+func (m *Composer) Send() error {
+	return m.SendContext(context.Background())
+}
+
+// SendContext - the same as Send, but the calls are bound to ctx.
+func (m *Composer) SendContext(ctx context.Context) error {
+	mail, err := m.build(ctx)
+	if err != nil {
+		return err
+	}
+	mail.Send = true
+	if _, err = m.conn.createMail(ctx, mail); err != nil {
+		return err
+	}
+	if m.original == nil || m.original.Id == "" {
+		return nil
+	}
+	return m.conn.setMailFlag(ctx, m.original.Id, m.flag)
+}
+
+// SaveDraft saves the mail to the Drafts folder and returns its id
+func (m *Composer) SaveDraft() (KId, error) {
+	return m.SaveDraftContext(context.Background())
+}
+
+// SaveDraftContext - the same as SaveDraft, but the calls are bound to ctx.
+func (m *Composer) SaveDraftContext(ctx context.Context) (KId, error) {
+	mail, err := m.build(ctx)
+	if err != nil {
+		return "", err
+	}
+	return m.conn.createMail(ctx, mail)
+}
+
+// Build returns the mail with the displayable parts made of Text, HTML and the quote
+func (m *Composer) Build() Mail {
+	mail := m.Mail
+	text := joinQuote(m.Text, m.quoteText, "\n\n")
+	htmlContent := m.HTML
+	if htmlContent == "" && m.quoteHTML != "" {
+		htmlContent = textToHTML(m.Text)
+	}
+	quoteHTML := m.quoteHTML
+	if quoteHTML == "" && htmlContent != "" && m.quoteText != "" {
+		quoteHTML = textToHTML(m.quoteText)
+	}
+	htmlContent = joinQuote(htmlContent, quoteHTML, "<br>")
+	mail.DisplayableParts = nil
+	if text != "" || htmlContent == "" {
+		mail.DisplayableParts = append(mail.DisplayableParts, DisplayableMimePart{ContentType: CtTextPlain, Content: text})
+	}
+	if htmlContent != "" {
+		mail.DisplayableParts = append(mail.DisplayableParts, DisplayableMimePart{ContentType: CtTextHtml, Content: htmlContent})
+	}
+	return mail
+}
+
+// build returns the mail to be created in the Drafts folder unless its folder is set
+func (m *Composer) build(ctx context.Context) (Mail, error) {
+	mail := m.Build()
+	if mail.FolderId != "" {
+		return mail, nil
+	}
+	if !m.draftsDone {
+		folders, err := m.conn.FoldersGetContext(ctx)
+		if err != nil {
+			return mail, err
+		}
+		for _, folder := range folders {
+			if folder.SubType == FSubDrafts {
+				m.draftsId = folder.Id
+				break
+			}
+		}
+		m.draftsDone = true
+	}
+	mail.FolderId = m.draftsId
+	return mail, nil
+}
+
+// createMail creates one mail and returns its id
+func (c *ClientConnection) createMail(ctx context.Context, mail Mail) (KId, error) {
+	errs, result, err := c.MailsCreateContext(ctx, MailList{mail})
+	if err != nil {
+		return "", err
+	}
+	if len(errs) > 0 {
+		return "", errs[0].ApiError()
+	}
+	if len(result) == 0 {
+		return "", errors.New("no result of mail creation")
+	}
+	return result[0].Id, nil
+}
+
+// setMailFlag sets one flag of the mail, the other fields are not changed
+func (c *ClientConnection) setMailFlag(ctx context.Context, id KId, flag string) error {
+	params := struct {
+		Mails []map[string]interface{} `json:"mails"`
+	}{[]map[string]interface{}{{"id": id, flag: true}}}
+	data, err := c.CallRawContext(ctx, "Mails.set", params)
+	if err != nil {
+		return err
+	}
+	return firstItemError(data)
+}
+
+func (c *ClientConnection) newReply(original Mail) *Composer {
+	composer := &Composer{conn: c, original: &original, flag: "isAnswered"}
+	composer.Mail.Subject = prefixSubject(ReplyPrefix, original.Subject)
+	if id := messageID(original); id != "" {
+		composer.Mail.Headers = MimeHeaderList{{Type: MhInReplayTo, Value: id}}
+	}
+	header := "On " + formatDate(original) + ", " + formatAddresses(EMailList{original.From}) + " wrote:"
+	if text, ok := plainContent(original); ok {
+		lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, ">") {
+				lines[i] = ">" + line
+			} else {
+				lines[i] = "> " + line
+			}
+		}
+		composer.quoteText = header + "\n" + strings.Join(lines, "\n")
+	}
+	if content, ok := partContent(original, CtTextHtml); ok {
+		composer.quoteHTML = "<div>" + html.EscapeString(header) + "</div><blockquote type=\"cite\">" + content + "</blockquote>"
+	}
+	return composer
+}
+
+// replyRecipients returns the Reply-To addresses of the mail, or its sender
+func replyRecipients(original Mail) EMailList {
+	if len(original.ReplyTo) > 0 {
+		return append(EMailList{}, original.ReplyTo...)
+	}
+	return EMailList{original.From}
+}
+
+// prefixSubject adds the prefix to the subject unless it is already there
+func prefixSubject(prefix, subject string) string {
+	if strings.HasPrefix(strings.ToLower(subject), strings.ToLower(prefix)) {
+		return subject
+	}
+	return prefix + subject
+}
+
+// messageID returns the value of Message-ID header of the mail
+func messageID(mail Mail) string {
+	for _, header := range mail.Headers {
+		if header.Type == MhMessageID {
+			return header.Value
+		}
+	}
+	return ""
+}
+
+// partContent returns the content of the first displayable part of the type
+func partContent(mail Mail, contentType DisplayableContentType) (string, bool) {
+	for _, part := range mail.DisplayableParts {
+		if part.ContentType == contentType {
+			return part.Content, true
+		}
+	}
+	return "", false
+}
+
+// plainContent returns the content of the plain text part, or of the HTML part converted to text
+func plainContent(mail Mail) (string, bool) {
+	if text, ok := partContent(mail, CtTextPlain); ok {
+		return text, true
+	}
+	if content, ok := partContent(mail, CtTextHtml); ok {
+		return HTMLToText(content), true
+	}
+	return "", false
+}
+
+func formatAddresses(list EMailList) string {
+	result := make([]string, 0, len(list))
+	for _, email := range list {
+		if email.Name != "" {
+			result = append(result, email.Name+" <"+email.Address+">")
+		} else {
+			result = append(result, email.Address)
+		}
+	}
+	return strings.Join(result, ", ")
+}
+
+func formatDate(mail Mail) string {
+	date := mail.SendDate
+	if date.IsZero() {
+		date = mail.ReceiveDate
+	}
+	t, err := date.Time()
+	if err != nil || t.IsZero() {
+		return string(date)
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+func joinQuote(content, quote, separator string) string {
+	if content == "" || quote == "" {
+		return content + quote
+	}
+	return content + separator + quote
+}
+
+// textToHTML returns the plain text as HTML
+func textToHTML(text string) string {
+	return strings.Replace(html.EscapeString(text), "\n", "<br>", -1)
+}
+
+// firstItemError returns the first error of "errors" member of the result
+func firstItemError(data []byte) error {
+	result := struct {
+		Result struct {
+			Errors ErrorList `json:"errors"`
+		} `json:"result"`
+	}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	if len(result.Result.Errors) > 0 {
+		return result.Result.Errors[0].ApiError()
+	}
+	return nil
+}
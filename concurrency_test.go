@@ -0,0 +1,81 @@
+package webmail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Run with -race to check the connection for data races
+func TestClientConnection_Concurrent(t *testing.T) {
+	var mu sync.Mutex
+	ids := map[int]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := parameters{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		mu.Lock()
+		if ids[req.ID] {
+			t.Errorf("duplicate request id %d", req.ID)
+		}
+		ids[req.ID] = true
+		mu.Unlock()
+		switch req.Method {
+		case "Session.login":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"token":"token"}}`, req.ID)
+		case "Mails.get":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"list":[{"id":"1"}],"totalItems":1}}`, req.ID)
+		case "Changes.get":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"list":[],"syncKey":{"id":1}}}`, req.ID)
+		default:
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"Method not found"}}`, req.ID)
+		}
+	}))
+	defer server.Close()
+	conf := NewConfig(strings.TrimPrefix(server.URL, "http://"), WithScheme("http"))
+	var conns []*ClientConnection
+	// the connection with options has a copy of the config, which shares the id counter
+	for _, options := range [][]Option{nil, {WithTimeout(time.Minute)}} {
+		conn, err := conf.NewConnection(options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns = append(conns, conn)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		conn := conns[i%len(conns)]
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if err := conn.Login("user", "password", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			list, total, err := conn.MailsGet(KIdList{"folder"}, SearchQuery{})
+			if err != nil || len(list) != 1 || total != 1 {
+				t.Errorf("unexpected result %v, %d, %v", list, total, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, syncKey, err := conn.ChangesGet(SyncKey{}, 0)
+			if err != nil || syncKey.Id != 1 {
+				t.Errorf("unexpected result %v, %v", syncKey, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ids) != 60 {
+		t.Errorf("expected 60 requests, got %d", len(ids))
+	}
+}
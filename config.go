@@ -1,8 +1,12 @@
 package webmail
 
 import (
+	"crypto/tls"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -24,24 +28,122 @@ type loginStruct struct {
 	Application ApiApplication `json:"application"`
 }
 
+// Config - settings of the API server. It is safe to share one Config between connections and goroutines.
 type Config struct {
-	url string
-	id  int
+	id           int64  // first for 64-bit alignment of atomic operations
+	ids          *int64 // id counter of the config the copy was made from, &id if nil
+	url          string // URL of JSON-RPC
+	root         string // URL of the web root, the relative URLs of downloads are resolved against it
+	server       string
+	scheme       string
+	basePath     string
+	client       *http.Client
+	transport    http.RoundTripper
+	tlsConfig    *tls.Config
+	timeout      time.Duration
+	retry        *RetryPolicy
+	interceptors []Interceptor
+	logger       Logger
+}
+
+// Option - optional setting of Config or ClientConnection
+type Option func(*Config)
+
+// WithScheme sets the URL scheme, "https" by default. Port 80 is used for "http" if server has no port.
+func WithScheme(scheme string) Option {
+	return func(c *Config) {
+		c.scheme = scheme
+	}
+}
+
+// WithBasePath sets the path prefix of the server, e.g. "/kerio" when the server is behind a reverse proxy.
+// All API paths (/webmail/api/...) are resolved under this prefix.
+func WithBasePath(basePath string) Option {
+	return func(c *Config) {
+		c.basePath = strings.TrimRight(basePath, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. A cookie jar is created if client has none.
+// It takes precedence over WithTransport and WithTLSConfig.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.client = client
+	}
+}
+
+// WithTransport sets the RoundTripper used for requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Config) {
+		c.transport = transport
+	}
+}
+
+// WithTLSConfig sets the TLS configuration, e.g. with a private CA in RootCAs or client certificates.
+// It is applied to the default transport or to the *http.Transport set by WithTransport,
+// NewConnection fails if WithTransport sets another RoundTripper, e.g. Recorder.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Config) {
+		c.tlsConfig = config
+	}
+}
+
+// WithTimeout sets the timeout of every API call, zero means no timeout.
+// Note that Changes.get waits up to its own timeout, so set it longer than that.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.timeout = timeout
+	}
 }
 
 // NewConfig returns a pointer to structure with the configuration for connecting to the API server
 //  server - address without schema and port
-func NewConfig(server string) *Config {
+//  options - optional settings of scheme, path and transport
+func NewConfig(server string, options ...Option) *Config {
+	c := &Config{
+		server: server,
+		scheme: "https",
+	}
+	c.apply(options)
+	return c
+}
+
+func (c *Config) apply(options []Option) {
+	for _, option := range options {
+		option(c)
+	}
+	server := c.server
 	if !strings.Contains(server, ":") {
-		server += port
+		if c.scheme == "http" {
+			server += ":80"
+		} else {
+			server += port
+		}
 	}
 	u := url.URL{
-		Scheme: "https",
+		Scheme: c.scheme,
 		Host:   server,
-		Path:   path,
+		Path:   c.basePath + path,
 	}
+	c.url = u.String()
+	u.Path = c.basePath
+	c.root = strings.TrimSuffix(u.String(), "/")
+}
+
+// clone returns a copy of the settings sharing the id counter
+func (c *Config) clone() *Config {
 	return &Config{
-		url: u.String(),
+		ids:          c.idCounter(),
+		server:       c.server,
+		scheme:       c.scheme,
+		basePath:     c.basePath,
+		client:       c.client,
+		transport:    c.transport,
+		tlsConfig:    c.tlsConfig,
+		timeout:      c.timeout,
+		retry:        c.retry,
+		interceptors: c.interceptors,
+		logger:       c.logger,
 	}
 }
 
@@ -64,6 +166,12 @@ func NewApplication(name, vendor, version string) *ApiApplication {
 }
 
 func (c *Config) getID() int {
-	c.id++
-	return c.id
+	return int(atomic.AddInt64(c.idCounter(), 1))
+}
+
+func (c *Config) idCounter() *int64 {
+	if c.ids != nil {
+		return c.ids
+	}
+	return &c.id
 }
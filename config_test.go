@@ -1,8 +1,12 @@
 package webmail
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,23 +18,86 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
-func TestConfig_NewSession(t *testing.T) {
-	param := struct {
-		Server   string `yaml:"server"`
-		User     string `yaml:"user"`
-		Password string `yaml:"password"`
-	}{}
+func TestNewConfig_Options(t *testing.T) {
+	conf := NewConfig("myserver.ru", WithScheme("http"), WithBasePath("/kerio/"), WithTimeout(time.Second))
+	if conf.url != "http://myserver.ru:80/kerio/webmail/api/jsonrpc" {
+		t.Errorf("invalid URL %s", conf.url)
+	}
+	tlsConfig := &tls.Config{ServerName: "kerio"}
+	conn, err := conf.NewConnection(WithScheme("https"), WithTLSConfig(tlsConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.Config.url != "https://myserver.ru:443/kerio/webmail/api/jsonrpc" || conn.Config.timeout != time.Second {
+		t.Errorf("invalid connection config %s", conn.Config.url)
+	}
+	if conf.url != "http://myserver.ru:80/kerio/webmail/api/jsonrpc" {
+		t.Error("connection options changed config")
+	}
+	transport, ok := conn.client.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig != tlsConfig {
+		t.Error("TLS config is not applied")
+	}
+	client := &http.Client{Timeout: time.Minute}
+	conn, err = NewConfig("myserver.ru", WithHTTPClient(client)).NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.client.Timeout != time.Minute || conn.client.Jar == nil || client.Jar != nil {
+		t.Error("HTTP client is not applied")
+	}
+	recorder := NewRecorder("cassette.json", nil)
+	if _, err = conf.NewConnection(WithTransport(recorder), WithTLSConfig(tlsConfig)); err == nil {
+		t.Error("TLS config can not be applied to Recorder")
+	}
+}
+
+// testParameters - test server from secret.yaml
+type testParameters struct {
+	Server   string `yaml:"server"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+}
+
+// readTestParameters returns the test server from secret.yaml and the options of the connection.
+// Without secret.yaml the calls are replayed from cassette, set WEBMAIL_RECORD=1 to record it.
+func readTestParameters(cassette string) (*testParameters, []Option, error) {
 	file, err := os.ReadFile("secret.yaml")
+	if os.IsNotExist(err) {
+		replayer, err := NewReplayer(cassette)
+		if err != nil {
+			return nil, nil, err
+		}
+		param := &testParameters{Server: "myserver.ru", Password: "password"}
+		for _, interaction := range replayer.cassette.Interactions {
+			if interaction.Method == "Session.login" {
+				login := loginStruct{}
+				_ = json.Unmarshal(interaction.Params, &login)
+				param.User = login.UserName
+			}
+		}
+		return param, []Option{WithTransport(replayer)}, nil
+	}
 	if err != nil {
-		t.Error(err)
-		return
+		return nil, nil, err
+	}
+	param := &testParameters{}
+	if err = yaml.Unmarshal(file, param); err != nil {
+		return nil, nil, err
 	}
-	err = yaml.Unmarshal(file, &param)
+	if os.Getenv("WEBMAIL_RECORD") != "" {
+		return param, []Option{WithTransport(NewRecorder(cassette, nil))}, nil
+	}
+	return param, nil, nil
+}
+
+func TestConfig_NewSession(t *testing.T) {
+	param, options, err := readTestParameters("testdata/session.json")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	conf := NewConfig(param.Server)
+	conf := NewConfig(param.Server, options...)
 	app := &ApiApplication{
 		Name:    "MyApp",
 		Vendor:  "Me",
@@ -51,3 +118,17 @@ func TestConfig_NewSession(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestReplayer_RoundTrip_NoBody(t *testing.T) {
+	replayer, err := NewReplayer("testdata/session.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest("GET", "https://myserver.ru/webmail/api/download/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = replayer.RoundTrip(req); err == nil {
+		t.Error("request without body must fail")
+	}
+}
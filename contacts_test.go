@@ -1,29 +1,14 @@
 package webmail
 
-import (
-	"os"
-	"testing"
-
-	"gopkg.in/yaml.v3"
-)
+import "testing"
 
 func TestConfig_ContactsRequests(t *testing.T) {
-	param := struct {
-		Server   string `yaml:"server"`
-		User     string `yaml:"user"`
-		Password string `yaml:"password"`
-	}{}
-	file, err := os.ReadFile("secret.yaml")
-	if err != nil {
-		t.Error(err)
-		return
-	}
-	err = yaml.Unmarshal(file, &param)
+	param, options, err := readTestParameters("testdata/contacts.json")
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	conf := NewConfig(param.Server)
+	conf := NewConfig(param.Server, options...)
 	app := &ApiApplication{
 		Name:    "MyApp",
 		Vendor:  "Me",
@@ -0,0 +1,128 @@
+package webmail
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Layouts of the date/time strings used by the API
+const (
+	UtcDateTimeLayout = "20060102T150405-0700" // date and time, e.g. "20210805T071500+0000"
+	UtcDateLayout     = "20060102"             // date of all-day items, e.g. "20210805"
+)
+
+// ErrInvalidDateTime - the value is not a date/time in the format of the API
+var ErrInvalidDateTime = errors.New("invalid date/time")
+
+// layouts accepted by parseDateTime, the server always sends the first one
+var dateTimeLayouts = []string{
+	"20060102T150405Z0700",
+	"20060102T150405",
+	UtcDateLayout,
+}
+
+// UtcDateTimeFromTime returns the date/time t in the format of the API, zero t gives the empty value
+func UtcDateTimeFromTime(t time.Time) UtcDateTime {
+	return UtcDateTime(formatDateTime(t))
+}
+
+// UtcDateFromTime returns the date of t (in its location) for all-day items, zero t gives the empty value
+func UtcDateFromTime(t time.Time) UtcDateTime {
+	if t.IsZero() {
+		return ""
+	}
+	return UtcDateTime(t.Format(UtcDateLayout))
+}
+
+// Time returns the date/time as time.Time in UTC.
+// The empty value gives zero time.Time, the date of all-day item gives its midnight in UTC.
+func (d UtcDateTime) Time() (time.Time, error) {
+	return d.TimeIn(time.UTC)
+}
+
+// TimeIn is like Time, but the result is in loc and the date of all-day item gives its midnight in loc
+func (d UtcDateTime) TimeIn(loc *time.Location) (time.Time, error) {
+	t, err := parseDateTime(string(d), loc)
+	if err != nil || t.IsZero() {
+		return t, err
+	}
+	return t.In(loc), nil
+}
+
+// IsZero reports whether the date/time is not set
+func (d UtcDateTime) IsZero() bool {
+	return d == ""
+}
+
+// IsAllDay reports whether the value contains the date only, as for all-day items
+func (d UtcDateTime) IsAllDay() bool {
+	return len(d) == len(UtcDateLayout)
+}
+
+// Validate returns an error wrapping ErrInvalidDateTime if the value is neither empty nor a valid date/time
+func (d UtcDateTime) Validate() error {
+	_, err := d.Time()
+	return err
+}
+
+// UtcTimeFromTime returns the time t in the format of the API, zero t gives the empty value
+func UtcTimeFromTime(t time.Time) UtcTime {
+	return UtcTime(formatDateTime(t))
+}
+
+// Time returns the time as time.Time in UTC, the empty value gives zero time.Time
+func (u UtcTime) Time() (time.Time, error) {
+	return UtcDateTime(u).Time()
+}
+
+// IsZero reports whether the time is not set
+func (u UtcTime) IsZero() bool {
+	return u == ""
+}
+
+// Validate returns an error wrapping ErrInvalidDateTime if the value is neither empty nor a valid time
+func (u UtcTime) Validate() error {
+	return UtcDateTime(u).Validate()
+}
+
+// DateTimeStampFromTime returns t as seconds since the Unix epoch, zero t gives 0
+func DateTimeStampFromTime(t time.Time) DateTimeStamp {
+	if t.IsZero() {
+		return 0
+	}
+	return DateTimeStamp(t.Unix())
+}
+
+// Time returns the time stamp as time.Time in UTC, 0 gives zero time.Time
+func (s DateTimeStamp) Time() time.Time {
+	if s == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(s), 0).UTC()
+}
+
+// IsZero reports whether the time stamp is not set
+func (s DateTimeStamp) IsZero() bool {
+	return s == 0
+}
+
+func formatDateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(UtcDateTimeLayout)
+}
+
+// parseDateTime parses the value in one of dateTimeLayouts, values without time zone are in loc
+func parseDateTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDateTime, value)
+}
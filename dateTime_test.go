@@ -0,0 +1,101 @@
+package webmail
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUtcDateTime_Time(t *testing.T) {
+	tests := []struct {
+		value UtcDateTime
+		want  time.Time
+	}{
+		{"20210805T071500+0000", time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)},
+		{"20210805T081500+0100", time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)},
+		{"20210805T071500Z", time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)},
+		{"20210805T071500", time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)},
+		{"20210805", time.Date(2021, 8, 5, 0, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+	}
+	for _, test := range tests {
+		got, err := test.value.Time()
+		if err != nil {
+			t.Errorf("%q: %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) || got.Location() != test.want.Location() {
+			t.Errorf("%q: got %v, want %v", test.value, got, test.want)
+		}
+	}
+	for _, value := range []UtcDateTime{"2021-08-05", "20211305T071500+0000", "20210805T0715", "yesterday"} {
+		if _, err := value.Time(); !errors.Is(err, ErrInvalidDateTime) {
+			t.Errorf("%q: got %v, want ErrInvalidDateTime", value, err)
+		}
+		if value.Validate() == nil {
+			t.Errorf("%q must not be valid", value)
+		}
+	}
+}
+
+func TestUtcDateTime_AllDay(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	date := UtcDateFromTime(time.Date(2021, 8, 5, 23, 30, 0, 0, loc))
+	if date != "20210805" || !date.IsAllDay() {
+		t.Fatalf("got %q", date)
+	}
+	got, err := date.TimeIn(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2021, 8, 5, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if UtcDateTime("20210805T071500+0000").IsAllDay() {
+		t.Error("date with time must not be all-day")
+	}
+}
+
+func TestUtcDateTime_FromTime(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	if got := UtcDateTimeFromTime(time.Date(2021, 8, 5, 9, 15, 0, 0, loc)); got != "20210805T071500+0000" {
+		t.Errorf("got %q", got)
+	}
+	if got := UtcDateTimeFromTime(time.Time{}); !got.IsZero() {
+		t.Errorf("zero time gives %q", got)
+	}
+	if got := UtcTimeFromTime(time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)); got != "20210805T071500+0000" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestUtcDateTime_JSON(t *testing.T) {
+	data := []byte(`{"start":"20210805T081500+0100","end":"","due":"20210806"}`)
+	alarm := struct {
+		Start UtcDateTime `json:"start"`
+		End   UtcDateTime `json:"end"`
+		Due   UtcDateTime `json:"due"`
+	}{}
+	if err := json.Unmarshal(data, &alarm); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(alarm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != string(data) {
+		t.Errorf("got %s, want %s", encoded, data)
+	}
+}
+
+func TestDateTimeStamp(t *testing.T) {
+	now := time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)
+	stamp := DateTimeStampFromTime(now)
+	if stamp != 1628147700 || !stamp.Time().Equal(now) {
+		t.Errorf("got %d, %v", stamp, stamp.Time())
+	}
+	if !DateTimeStampFromTime(time.Time{}).IsZero() || !DateTimeStamp(0).Time().IsZero() {
+		t.Error("zero time stamp must give zero time")
+	}
+}
@@ -0,0 +1,159 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DownloadMethod - method of CallRecord of a download
+const DownloadMethod = "download"
+
+// ErrSizeMismatch - the size of downloaded file differs from the size reported by the server
+var ErrSizeMismatch = errors.New("downloaded size mismatch")
+
+// maxErrorSize - limit of the body of failed download read to find the error report
+const maxErrorSize = 64 << 10
+
+// Download writes the file from url to w and returns the number of written bytes.
+// The URL relative to the root of web (e.g. Attachment.Url, PhotoAttachment.Url or Image.Url) is resolved
+// against the server, the session token is sent only to the server of the connection.
+// The download is sent again after auto re-login if the session expired.
+func (c *ClientConnection) Download(w io.Writer, url string) (int64, error) {
+	return c.DownloadContext(context.Background(), w, url)
+}
+
+// DownloadContext - the same as Download, but the request is bound to ctx.
+func (c *ClientConnection) DownloadContext(ctx context.Context, w io.Writer, url string) (int64, error) {
+	u, err := c.Config.resolve(url)
+	if err != nil {
+		return 0, err
+	}
+	if !c.Config.isServer(u) {
+		return c.download(ctx, w, u, nil)
+	}
+	token := c.token()
+	n, err := c.download(ctx, w, u, token)
+	if err != nil && n == 0 && c.needRelogin(DownloadMethod, err) {
+		if err = c.reloginContext(ctx, token); err != nil {
+			return 0, err
+		}
+		n, err = c.download(ctx, w, u, c.token())
+	}
+	return n, err
+}
+
+// download performs a single download with the token, the timeout of the config applies to the whole transfer
+func (c *ClientConnection) download(ctx context.Context, w io.Writer, u *url.URL, token *string) (int64, error) {
+	if c.Config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	n, err := c.get(ctx, w, u, token)
+	c.logCall(ctx, CallRecord{
+		Method:       DownloadMethod,
+		Duration:     time.Since(start),
+		ResponseSize: int(n),
+		Err:          err,
+	}, token, nil)
+	return n, err
+}
+
+// get writes the body of the response to the GET request of u to w
+func (c *ClientConnection) get(ctx context.Context, w io.Writer, u *url.URL, token *string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header = newRequestHeader(ctx, token)
+	req.Header.Del("Content-Type")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+		if err = checkResponse(resp, data); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("download of %s: %s", u, resp.Status)
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// DownloadAttachment writes the attachment to w and checks its size
+func (c *ClientConnection) DownloadAttachment(w io.Writer, attachment Attachment) error {
+	return c.DownloadAttachmentContext(context.Background(), w, attachment)
+}
+
+// DownloadAttachmentContext - the same as DownloadAttachment, but the request is bound to ctx.
+func (c *ClientConnection) DownloadAttachmentContext(ctx context.Context, w io.Writer, attachment Attachment) error {
+	return c.downloadSize(ctx, w, attachment.Url, attachment.Size)
+}
+
+// DownloadFile writes the file prepared for download (e.g. by MailsExportAttachments or CertificatesExportPKCS12)
+// to w and checks its length
+func (c *ClientConnection) DownloadFile(w io.Writer, download Download) error {
+	return c.DownloadFileContext(context.Background(), w, download)
+}
+
+// DownloadFileContext - the same as DownloadFile, but the request is bound to ctx.
+func (c *ClientConnection) DownloadFileContext(ctx context.Context, w io.Writer, download Download) error {
+	return c.downloadSize(ctx, w, download.Url, download.Length)
+}
+
+// downloadSize downloads the file and checks its size, size 0 is not checked
+func (c *ClientConnection) downloadSize(ctx context.Context, w io.Writer, url string, size int) error {
+	n, err := c.DownloadContext(ctx, w, url)
+	if err != nil {
+		return err
+	}
+	if size > 0 && n != int64(size) {
+		return fmt.Errorf("%w: %s has %d bytes, expected %d", ErrSizeMismatch, url, n, size)
+	}
+	return nil
+}
+
+// resolve returns the absolute URL, ref relative to the root of web is resolved against the server
+func (c *Config) resolve(ref string) (*url.URL, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	if u.IsAbs() {
+		return u, nil
+	}
+	if ref == "" {
+		return nil, errors.New("empty download URL")
+	}
+	if !strings.HasPrefix(ref, "/") {
+		ref = "/" + ref
+	}
+	return url.Parse(c.root + ref)
+}
+
+// isServer reports whether u points to the server of the config
+func (c *Config) isServer(u *url.URL) bool {
+	root, err := url.Parse(c.root)
+	return err == nil && strings.EqualFold(u.Scheme, root.Scheme) && strings.EqualFold(hostPort(u), hostPort(root))
+}
+
+// hostPort returns the host of u with the port, the default port of the scheme is added if missing
+func hostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if strings.EqualFold(u.Scheme, "http") {
+		return u.Host + ":80"
+	}
+	return u.Host + port
+}
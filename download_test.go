@@ -0,0 +1,67 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfig_Resolve(t *testing.T) {
+	config := NewConfig("mail.example.com", WithBasePath("/kerio"))
+	tests := []struct {
+		ref    string
+		want   string
+		server bool
+	}{
+		{"/webmail/api/download/attachment/1/picture.jpg", "https://mail.example.com:443/kerio/webmail/api/download/attachment/1/picture.jpg", true},
+		{"webmail/api/download/Q3%20report.pdf", "https://mail.example.com:443/kerio/webmail/api/download/Q3%20report.pdf", true},
+		{"https://mail.example.com/webmail/image.png", "https://mail.example.com/webmail/image.png", true},
+		{"http://mail.example.com/webmail/image.png", "http://mail.example.com/webmail/image.png", false},
+		{"https://cdn.example.com/image.png", "https://cdn.example.com/image.png", false},
+	}
+	for _, test := range tests {
+		u, err := config.resolve(test.ref)
+		if err != nil {
+			t.Errorf("%s: %v", test.ref, err)
+			continue
+		}
+		if u.String() != test.want {
+			t.Errorf("%s: got %s, want %s", test.ref, u, test.want)
+		}
+		if config.isServer(u) != test.server {
+			t.Errorf("%s: isServer %v, want %v", test.ref, !test.server, test.server)
+		}
+	}
+	if _, err := config.resolve(""); err == nil {
+		t.Error("empty URL must fail")
+	}
+}
+
+func TestClientConnection_Download_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+	var records []CallRecord
+	conf := NewConfig("", WithTimeout(10*time.Millisecond), WithLogger(LoggerFunc(func(record CallRecord) {
+		records = append(records, record)
+	})))
+	conf.url, conf.root = server.URL+"/webmail/api/jsonrpc/", server.URL
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.Download(ioutil.Discard, "/webmail/api/download/1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want timeout", err)
+	}
+	if len(records) != 1 || records[0].Method != DownloadMethod || records[0].Err == nil {
+		t.Errorf("got records %+v", records)
+	}
+}
@@ -0,0 +1,540 @@
+package webmail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// AttachmentWriter - writes the content of the attachment to w, see WriteEML
+type AttachmentWriter func(w io.Writer, attachment Attachment) error
+
+// AttachmentData - attachment of the parsed message with its content, see ParseEML
+type AttachmentData struct {
+	Attachment Attachment // attachment without Id, which is assigned by upload
+	Data       []byte
+}
+
+// names of message headers with the values of MimeHeaderType
+var mimeHeaderNames = []struct {
+	name string
+	typ  MimeHeaderType
+}{
+	{"Message-ID", MhMessageID},
+	{"In-Reply-To", MhInReplayTo},
+	{"Resent-Message-ID", MhResentMessageID},
+}
+
+// ExportEML writes the mail as MIME message (.eml file), the attachments are downloaded from the server.
+// The mail must be read with its headers, displayable parts and attachments, e.g. by MailsGetById.
+func (c *ClientConnection) ExportEML(w io.Writer, mail Mail) error {
+	return c.ExportEMLContext(context.Background(), w, mail)
+}
+
+// ExportEMLContext - the same as ExportEML, but the downloads are bound to ctx.
+func (c *ClientConnection) ExportEMLContext(ctx context.Context, w io.Writer, mail Mail) error {
+	return WriteEML(w, mail, func(w io.Writer, attachment Attachment) error {
+		return c.DownloadAttachmentContext(ctx, w, attachment)
+	})
+}
+
+// ImportEML parses the MIME message, uploads its attachments and creates the mail in the folder.
+// Return
+//	id - global identification of the created mail
+func (c *ClientConnection) ImportEML(r io.Reader, folderId KId) (KId, error) {
+	return c.ImportEMLContext(context.Background(), r, folderId)
+}
+
+// ImportEMLContext - the same as ImportEML, but the calls are bound to ctx.
+func (c *ClientConnection) ImportEMLContext(ctx context.Context, r io.Reader, folderId KId) (KId, error) {
+	m, attachments, err := ParseEML(r)
+	if err != nil {
+		return "", err
+	}
+	m.FolderId = folderId
+	return c.createMailWithAttachments(ctx, *m, attachments)
+}
+
+// createMailWithAttachments uploads the attachments, adds them to the mail and creates it
+func (c *ClientConnection) createMailWithAttachments(ctx context.Context, m Mail, attachments []AttachmentData) (KId, error) {
+	m.Id = ""
+	m.Attachments = nil
+	for _, data := range attachments {
+		attachment := data.Attachment
+		name := attachment.Name
+		if name == "" {
+			name = "attachment"
+		}
+		id, err := c.UploadContext(ctx, bytes.NewReader(data.Data), name, attachment.ContentType)
+		if err != nil {
+			return "", err
+		}
+		attachment.Id = id
+		m.Attachments = append(m.Attachments, attachment)
+	}
+	return c.createMail(ctx, m)
+}
+
+// WriteEML writes the mail as MIME message (RFC 5322) to w.
+// The text and HTML displayable parts become multipart/alternative, the attachments with ContentId
+// are related to them and the other attachments are mixed, their content is written by attachment.
+func WriteEML(w io.Writer, m Mail, attachment AttachmentWriter) error {
+	buffer := bufio.NewWriter(w)
+	body := newMessageBody(m, attachment)
+	if err := writeMessageHeader(buffer, m); err != nil {
+		return err
+	}
+	if err := body.writeHeader(buffer); err != nil {
+		return err
+	}
+	if err := body.writeBody(buffer); err != nil {
+		return err
+	}
+	return buffer.Flush()
+}
+
+// ParseEML parses the MIME message. It returns the mail without Id and FolderId
+// and the attachments, which must be uploaded before the mail is created.
+// The texts are decoded from the charsets of the WHATWG Encoding Standard, the ones in unknown charsets are kept as they are.
+func ParseEML(r io.Reader) (*Mail, []AttachmentData, error) {
+	m, attachments, _, err := parseEML(r)
+	return m, attachments, err
+}
+
+// parseEML returns also the header of the message with the fields not mapped to Mail
+func parseEML(r io.Reader) (*Mail, []AttachmentData, mail.Header, error) {
+	message, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	header := message.Header
+	m := &Mail{Priority: Normal}
+	decoder := &mime.WordDecoder{CharsetReader: charsetReader}
+	if subject, err := decoder.DecodeHeader(header.Get("Subject")); err == nil {
+		m.Subject = subject
+	} else {
+		m.Subject = header.Get("Subject")
+	}
+	if date, err := header.Date(); err == nil {
+		m.SendDate = UtcDateTimeFromTime(date)
+	}
+	var from, sender, notification EMailList
+	for _, field := range []struct {
+		name string
+		list *EMailList
+	}{
+		{"From", &from},
+		{"Sender", &sender},
+		{"Disposition-Notification-To", &notification},
+		{"To", &m.To},
+		{"Cc", &m.Cc},
+		{"Bcc", &m.Bcc},
+		{"Reply-To", &m.ReplyTo},
+	} {
+		if *field.list, err = parseAddresses(header, field.name); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if len(from) > 0 {
+		m.From = from[0]
+	}
+	if len(sender) > 0 {
+		m.Sender = sender[0]
+	}
+	if len(notification) > 0 {
+		m.NotificationTo = notification[0]
+	}
+	for _, h := range mimeHeaderNames {
+		if value := header.Get(h.name); value != "" {
+			m.Headers = append(m.Headers, MimeHeader{Type: h.typ, Value: value})
+		}
+	}
+	// X-Priority is a digit optionally followed by a comment, e.g. "1 (Highest)"
+	if priority := strings.TrimSpace(header.Get("X-Priority")); priority != "" {
+		switch priority[0] {
+		case '1', '2':
+			m.Priority = High
+		case '4', '5':
+			m.Priority = Low
+		}
+	}
+	var attachments []AttachmentData
+	if err = parseMessagePart(textproto.MIMEHeader(header), message.Body, m, &attachments, decoder); err != nil {
+		return nil, nil, nil, err
+	}
+	return m, attachments, header, nil
+}
+
+// messagePart - part of the MIME message being written
+type messagePart struct {
+	header   textproto.MIMEHeader
+	body     func(w io.Writer) error // content of single part
+	boundary string                  // boundary of multipart
+	parts    []*messagePart
+}
+
+// newMessageBody returns the tree of MIME parts of the mail
+func newMessageBody(m Mail, attachment AttachmentWriter) *messagePart {
+	var texts []*messagePart
+	for _, contentType := range []DisplayableContentType{CtTextPlain, CtTextHtml} {
+		for _, part := range m.DisplayableParts {
+			if part.ContentType == contentType {
+				texts = append(texts, newTextPart(part))
+			}
+		}
+	}
+	if len(texts) == 0 {
+		texts = append(texts, newTextPart(DisplayableMimePart{ContentType: CtTextPlain}))
+	}
+	body := texts[0]
+	if len(texts) > 1 {
+		body = newMultipart("multipart/alternative", texts)
+	}
+	var inline, mixed []*messagePart
+	for _, a := range m.Attachments {
+		if a.ContentId != "" {
+			inline = append(inline, newAttachmentPart(a, attachment))
+		} else {
+			mixed = append(mixed, newAttachmentPart(a, attachment))
+		}
+	}
+	if len(inline) > 0 {
+		body = newMultipart("multipart/related", append([]*messagePart{body}, inline...))
+	}
+	if len(mixed) > 0 {
+		body = newMultipart("multipart/mixed", append([]*messagePart{body}, mixed...))
+	}
+	return body
+}
+
+func newTextPart(part DisplayableMimePart) *messagePart {
+	contentType := "text/plain"
+	if part.ContentType == CtTextHtml {
+		contentType = "text/html"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": "utf-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return &messagePart{
+		header: header,
+		body: func(w io.Writer) error {
+			qp := quotedprintable.NewWriter(w)
+			if _, err := io.WriteString(qp, part.Content); err != nil {
+				return err
+			}
+			if err := qp.Close(); err != nil {
+				return err
+			}
+			// soft line break ends the last line without adding the line end to the content, as mbox requires
+			if part.Content != "" && !strings.HasSuffix(part.Content, "\n") {
+				_, err := io.WriteString(w, "=\r\n")
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func newAttachmentPart(a Attachment, attachment AttachmentWriter) *messagePart {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = octetStream
+	}
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+	if a.ContentId != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+a.ContentId+">")
+	}
+	typeParams, dispositionParams := map[string]string{}, map[string]string{}
+	if a.Name != "" {
+		typeParams["name"] = a.Name
+		dispositionParams["filename"] = a.Name
+	}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, typeParams))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, dispositionParams))
+	header.Set("Content-Transfer-Encoding", "base64")
+	return &messagePart{
+		header: header,
+		body: func(w io.Writer) error {
+			lines := &lineWriter{w: w, length: 76}
+			encoder := base64.NewEncoder(base64.StdEncoding, lines)
+			if err := attachment(encoder, a); err != nil {
+				return fmt.Errorf("attachment %s: %w", a.Name, err)
+			}
+			if err := encoder.Close(); err != nil {
+				return err
+			}
+			return lines.end()
+		},
+	}
+}
+
+func newMultipart(contentType string, parts []*messagePart) *messagePart {
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"boundary": boundary}))
+	return &messagePart{header: header, boundary: boundary, parts: parts}
+}
+
+// writeHeader writes the MIME headers of the top part of the message
+func (p *messagePart) writeHeader(w *bufio.Writer) error {
+	for _, name := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := p.header.Get(name); value != "" {
+			if _, err := fmt.Fprintf(w, "%s: %s\r\n", name, value); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := w.WriteString("\r\n")
+	return err
+}
+
+func (p *messagePart) writeBody(w io.Writer) error {
+	if p.parts == nil {
+		return p.body(w)
+	}
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(p.boundary); err != nil {
+		return err
+	}
+	for _, part := range p.parts {
+		partWriter, err := writer.CreatePart(part.header)
+		if err != nil {
+			return err
+		}
+		if err = part.writeBody(partWriter); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// writeMessageHeader writes the headers of the message except the ones of its content
+func writeMessageHeader(w *bufio.Writer, m Mail) error {
+	var lines []string
+	add := func(name, value string) {
+		// CR and LF of the values are stripped, they can not start a new header
+		value = strings.TrimSpace(headerBreaks.Replace(value))
+		if value != "" {
+			lines = append(lines, name+": "+value)
+		}
+	}
+	date := m.SendDate
+	if date.IsZero() {
+		date = m.ReceiveDate
+	}
+	if t, err := date.Time(); err == nil && !t.IsZero() {
+		add("Date", t.Format(time.RFC1123Z))
+	}
+	add("From", formatMailAddresses(EMailList{m.From}))
+	if m.Sender.Address != "" && !strings.EqualFold(m.Sender.Address, m.From.Address) {
+		add("Sender", formatMailAddresses(EMailList{m.Sender}))
+	}
+	add("To", formatMailAddresses(m.To))
+	add("Cc", formatMailAddresses(m.Cc))
+	add("Bcc", formatMailAddresses(m.Bcc))
+	add("Reply-To", formatMailAddresses(m.ReplyTo))
+	add("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	for _, h := range mimeHeaderNames {
+		for _, header := range m.Headers {
+			if header.Type == h.typ {
+				add(h.name, header.Value)
+			}
+		}
+	}
+	add("Disposition-Notification-To", formatMailAddresses(EMailList{m.NotificationTo}))
+	switch m.Priority {
+	case High:
+		add("X-Priority", "1 (Highest)")
+	case Low:
+		add("X-Priority", "5 (Lowest)")
+	}
+	add("MIME-Version", "1.0")
+	for _, line := range lines {
+		if _, err := w.WriteString(line + "\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headerBreaks - replacer of the line breaks in header values
+var headerBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// formatMailAddresses returns the addresses for message header, empty addresses are skipped
+func formatMailAddresses(list EMailList) string {
+	var result []string
+	for _, email := range list {
+		if email.Address == "" {
+			continue
+		}
+		result = append(result, (&mail.Address{Name: email.Name, Address: email.Address}).String())
+	}
+	return strings.Join(result, ", ")
+}
+
+// addressParser - parser of the address lists with the display names in any charset
+var addressParser = &mail.AddressParser{WordDecoder: &mime.WordDecoder{CharsetReader: charsetReader}}
+
+func parseAddresses(header mail.Header, name string) (EMailList, error) {
+	value := header.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	addresses, err := addressParser.ParseList(value)
+	if err != nil {
+		return nil, fmt.Errorf("header %s: %w", name, err)
+	}
+	list := make(EMailList, len(addresses))
+	for i, address := range addresses {
+		list[i] = EMail{Name: address.Name, Address: address.Address}
+	}
+	return list, nil
+}
+
+// parseMessagePart adds the displayable parts and attachments of the MIME part
+func parseMessagePart(header textproto.MIMEHeader, body io.Reader, m *Mail, attachments *[]AttachmentData, decoder *mime.WordDecoder) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err = parseMessagePart(part.Header, part, m, attachments, decoder); err != nil {
+				return err
+			}
+		}
+	}
+	data, err := ioutil.ReadAll(transferDecoder(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	name := dispositionParams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	if decoded, err := decoder.DecodeHeader(name); err == nil {
+		name = decoded
+	}
+	if (mediaType == "text/plain" || mediaType == "text/html") && disposition != "attachment" && name == "" {
+		contentType := CtTextPlain
+		if mediaType == "text/html" {
+			contentType = CtTextHtml
+		}
+		content, err := decodeCharset(data, params["charset"])
+		if err != nil {
+			return err
+		}
+		content = strings.Replace(content, "\r\n", "\n", -1)
+		m.DisplayableParts = append(m.DisplayableParts, DisplayableMimePart{ContentType: contentType, Content: content})
+		return nil
+	}
+	*attachments = append(*attachments, AttachmentData{
+		Attachment: Attachment{
+			Name:        name,
+			ContentType: mediaType,
+			ContentId:   strings.Trim(header.Get("Content-Id"), "<> "),
+			Size:        len(data),
+		},
+		Data: data,
+	})
+	return nil
+}
+
+// transferDecoder returns the reader decoding the content transfer encoding
+func transferDecoder(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// decodeCharset returns the text in UTF-8, the text in an unknown charset is returned as it is
+func decodeCharset(data []byte, charset string) (string, error) {
+	reader, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	text, err := ioutil.ReadAll(reader)
+	return string(text), err
+}
+
+// charsetReader returns the reader decoding the charset to UTF-8 by the names of the WHATWG Encoding Standard,
+// the input of an unknown charset is read as it is
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8", "us-ascii":
+		return input, nil
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return input, nil
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// lineWriter - writer breaking the output to lines of the length
+type lineWriter struct {
+	w      io.Writer
+	length int
+	column int
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := l.length - l.column
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := l.w.Write(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		l.column += n
+		p = p[n:]
+		if l.column == l.length {
+			if _, err := io.WriteString(l.w, "\r\n"); err != nil {
+				return written, err
+			}
+			l.column = 0
+		}
+	}
+	return written, nil
+}
+
+// end terminates the last line
+func (l *lineWriter) end() error {
+	if l.column == 0 {
+		return nil
+	}
+	l.column = 0
+	_, err := io.WriteString(l.w, "\r\n")
+	return err
+}
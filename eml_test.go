@@ -0,0 +1,157 @@
+package webmail
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEML_RoundTrip(t *testing.T) {
+	mail := Mail{
+		Subject:  "Zpráva Q3",
+		From:     EMail{Name: "Alice Nováková", Address: "alice@example.com"},
+		To:       EMailList{{Address: "bob@example.com"}, {Name: "Carol", Address: "carol@example.com"}},
+		Cc:       EMailList{{Address: "dave@example.com"}},
+		ReplyTo:  EMailList{{Address: "team@example.com"}},
+		SendDate: UtcDateTimeFromTime(time.Date(2020, 3, 14, 9, 26, 53, 0, time.UTC)),
+		Priority: High,
+		Headers:  MimeHeaderList{{Type: MhMessageID, Value: "<1@example.com>"}},
+		DisplayableParts: DisplayableMimePartList{
+			{ContentType: CtTextHtml, Content: `<p>Hello <img src="cid:logo"></p>`},
+			{ContentType: CtTextPlain, Content: "Hello,\nsee the report = numbers"},
+		},
+		Attachments: AttachmentList{
+			{Name: "logo.png", ContentType: "image/png", ContentId: "logo"},
+			{Name: "report č.pdf", ContentType: "application/pdf"},
+		},
+	}
+	content := map[string][]byte{
+		"logo.png":     {0x89, 'P', 'N', 'G'},
+		"report č.pdf": bytes.Repeat([]byte("%PDF-1.4 "), 20),
+	}
+	buffer := &bytes.Buffer{}
+	err := WriteEML(buffer, mail, func(w io.Writer, attachment Attachment) error {
+		_, err := w.Write(content[attachment.Name])
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(buffer.String(), "\r\n") {
+		if len(line) > 998 {
+			t.Errorf("line too long: %d", len(line))
+		}
+	}
+
+	parsed, attachments, err := ParseEML(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Subject != mail.Subject || parsed.From != mail.From || parsed.Priority != High || parsed.SendDate != mail.SendDate {
+		t.Errorf("got %+v", parsed)
+	}
+	if len(parsed.To) != 2 || parsed.To[1] != mail.To[1] || len(parsed.Cc) != 1 || len(parsed.ReplyTo) != 1 {
+		t.Errorf("recipients %+v, %+v, %+v", parsed.To, parsed.Cc, parsed.ReplyTo)
+	}
+	if messageID(*parsed) != "<1@example.com>" {
+		t.Errorf("headers %+v", parsed.Headers)
+	}
+	if len(parsed.DisplayableParts) != 2 || parsed.DisplayableParts[0] != mail.DisplayableParts[1] ||
+		parsed.DisplayableParts[1] != mail.DisplayableParts[0] {
+		t.Errorf("parts %+v", parsed.DisplayableParts)
+	}
+	if len(attachments) != 2 {
+		t.Fatalf("got %d attachments", len(attachments))
+	}
+	for i, data := range attachments {
+		want := mail.Attachments[i]
+		if data.Attachment.Name != want.Name || data.Attachment.ContentType != want.ContentType ||
+			data.Attachment.ContentId != want.ContentId || !bytes.Equal(data.Data, content[want.Name]) ||
+			data.Attachment.Size != len(data.Data) {
+			t.Errorf("attachment %d: got %+v", i, data.Attachment)
+		}
+	}
+}
+
+func TestParseEML(t *testing.T) {
+	message := "From: =?iso-8859-1?q?J=F6rg?= <jorg@example.com>\r\n" +
+		"To: bob@example.com\r\n" +
+		"Subject: =?utf-8?b?xb1sdcWlb3XEjWvDvQ==?=\r\n" +
+		"X-Priority: 5\r\n" +
+		"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Gr=FC=DFe\r\n"
+	mail, attachments, err := ParseEML(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mail.From.Name != "Jörg" || mail.Subject != "Žluťoučký" || mail.Priority != Low || len(attachments) != 0 {
+		t.Errorf("got %+v", mail)
+	}
+	if len(mail.DisplayableParts) != 1 || mail.DisplayableParts[0].Content != "Grüße\n" {
+		t.Errorf("parts %+v", mail.DisplayableParts)
+	}
+}
+
+func TestParseEML_Charsets(t *testing.T) {
+	message := "Content-Type: text/plain; charset=windows-1252\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"=80 5 =93quoted=94 =96 dash"
+	mail, _, err := ParseEML(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mail.DisplayableParts) != 1 || mail.DisplayableParts[0].Content != "€ 5 “quoted” – dash" {
+		t.Errorf("parts %+v", mail.DisplayableParts)
+	}
+	message = "From: =?windows-1252?q?=93Ren=E9=94?= <rene@example.com>\r\n" +
+		"Content-Type: text/plain; charset=koi8-r\r\n\r\n\xf0\xd2\xc9\xd7\xc5\xd4"
+	if mail, _, err = ParseEML(strings.NewReader(message)); err != nil {
+		t.Fatal(err)
+	}
+	if mail.From.Name != "“René”" || mail.From.Address != "rene@example.com" || mail.DisplayableParts[0].Content != "Привет" {
+		t.Errorf("got %+v", mail)
+	}
+	message = "Content-Type: text/plain; charset=x-unknown\r\n\r\nraw \xff"
+	if mail, _, err = ParseEML(strings.NewReader(message)); err != nil || mail.DisplayableParts[0].Content != "raw \xff" {
+		t.Errorf("got %+v, %v", mail, err)
+	}
+	if _, _, err = ParseEML(strings.NewReader("From: <broken\r\n\r\n")); err == nil {
+		t.Error("invalid address must fail")
+	}
+}
+
+func TestWriteEML_AttachmentError(t *testing.T) {
+	mail := Mail{Attachments: AttachmentList{{Name: "a.txt"}}}
+	errFailed := errors.New("failed")
+	err := WriteEML(&bytes.Buffer{}, mail, func(io.Writer, Attachment) error { return errFailed })
+	if !errors.Is(err, errFailed) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestWriteEML_HeaderInjection(t *testing.T) {
+	mail := Mail{
+		From:    EMail{Address: "alice@example.com"},
+		Headers: MimeHeaderList{{Type: MhMessageID, Value: "<1@example.com>\r\nBcc: eve@example.com\nX-Injected: 1\r"}},
+	}
+	buffer := &bytes.Buffer{}
+	if err := WriteEML(buffer, mail, nil); err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, err := ParseEML(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Bcc) != 0 || strings.Contains(buffer.String(), "\r\nX-Injected") {
+		t.Errorf("header injected: %q", buffer.String())
+	}
+	if messageID(*parsed) != "<1@example.com> Bcc: eve@example.com X-Injected: 1" {
+		t.Errorf("headers %+v", parsed.Headers)
+	}
+}
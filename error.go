@@ -20,11 +20,78 @@ type errorReport struct {
 	ErrorReport `json:"error"`
 }
 
+// ApiError - error returned by the API server. Use errors.Is with one of the Err* values to check the code,
+// or errors.As to get the message parameters.
+type ApiError struct {
+	Code                 int      // one of the ErrorCode* constants
+	Message              string   // text with placeholders %1, %2, etc.
+	PositionalParameters []string // strings to replace the placeholders in message
+	Plurality            int      // count of items, used to distinguish among singular/paucal/plural
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// Is reports whether target is an *ApiError with the same code
+func (e *ApiError) Is(target error) bool {
+	t, ok := target.(*ApiError)
+	return ok && t.Code == e.Code
+}
+
+// Sentinel errors for the documented error codes, to be used with errors.Is
+var (
+	ErrParseError                    = &ApiError{Code: ErrorCodeParseError, Message: "Parse error"}
+	ErrInternalError                 = &ApiError{Code: ErrorCodeInternalError, Message: "Internal error"}
+	ErrInvalidParams                 = &ApiError{Code: ErrorCodeInvalidParams, Message: "Invalid params"}
+	ErrMethodNotFound                = &ApiError{Code: ErrorCodeMethodNotFound, Message: "Method not found"}
+	ErrInvalidRequest                = &ApiError{Code: ErrorCodeInvalidRequest, Message: "Invalid request"}
+	ErrMultiServerBackendMaintenance = &ApiError{Code: ErrorCodeMultiServerBackendMaintenance, Message: "Backend maintenance"}
+	ErrTimedout                      = &ApiError{Code: ErrorCodeTimedout, Message: "Timed out"}
+	ErrSessionExpired                = &ApiError{Code: ErrorCodeSessionExpired, Message: "Session expired"}
+	ErrCommunicationFailure          = &ApiError{Code: ErrorCodeCommunicationFailure, Message: "Communication failure"}
+	ErrRequestEntityTooLarge         = &ApiError{Code: ErrorCodeRequestEntityTooLarge, Message: "Request entity too large"}
+	ErrOperationFailed               = &ApiError{Code: ErrorCodeOperationFailed, Message: "Operation failed"}
+	ErrAlreadyExists                 = &ApiError{Code: ErrorCodeAlreadyExists, Message: "Already exists"}
+	ErrNoSuchEntity                  = &ApiError{Code: ErrorCodeNoSuchEntity, Message: "No such entity"}
+	ErrNotPermitted                  = &ApiError{Code: ErrorCodeNotPermitted, Message: "Not permitted"}
+	ErrAccessDenied                  = &ApiError{Code: ErrorCodeAccessDenied, Message: "Access denied"}
+	ErrDangerousOperation            = &ApiError{Code: ErrorCodeDangerousOperation, Message: "Dangerous operation"}
+	ErrPartialSuccess                = &ApiError{Code: ErrorCodePartialSuccess, Message: "Partial success"}
+	ErrChangePswFailed               = &ApiError{Code: ErrorCodeChangePswFailed, Message: "Failed to change password"}
+	ErrFolderReindexing              = &ApiError{Code: ErrorCodeFolderReindexing, Message: "Folder is being reindexed"}
+	ErrOperationInProgress           = &ApiError{Code: ErrorCodeOperationInProgress, Message: "Operation in progress"}
+	ErrQuotaReached                  = &ApiError{Code: ErrorCodeQuotaReached, Message: "Quota reached"}
+	ErrSendingFailed                 = &ApiError{Code: ErrorCodeSendingFailed, Message: "Sending failed"}
+	ErrNoSuchFolder                  = &ApiError{Code: ErrorCodeNoSuchFolder, Message: "No such folder"}
+	ErrOperatorSessionExpired        = &ApiError{Code: ErrorCodeOperatorSessionExpired, Message: "Operator session expired"}
+)
+
+// newApiError converts the error report from server reply to *ApiError
+func newApiError(report ErrorReport) *ApiError {
+	return &ApiError{
+		Code:                 report.Code,
+		Message:              report.Message,
+		PositionalParameters: report.Data.MessageParameters.PositionalParameters,
+		Plurality:            report.Data.MessageParameters.Plurality,
+	}
+}
+
 func checkError(data []byte) error {
 	errorReport := errorReport{}
 	_ = json.Unmarshal(data, &errorReport)
 	if errorReport.Code == 0 && errorReport.Message == "" {
 		return nil
 	}
-	return fmt.Errorf("%d: %s", errorReport.Code, errorReport.Message)
+	return newApiError(errorReport.ErrorReport)
+}
+
+// ApiError returns the error of the item as *ApiError, so it can be checked with errors.Is
+func (e Error) ApiError() *ApiError {
+	return &ApiError{
+		Code:                 e.Code,
+		Message:              e.Message,
+		PositionalParameters: e.MessageParameters.PositionalParameters,
+		Plurality:            e.MessageParameters.Plurality,
+	}
 }
@@ -0,0 +1,33 @@
+package webmail
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCheckError(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"Session expired.",` +
+		`"data":{"messageParameters":{"positionalParameters":["a"],"plurality":1}}}}`)
+	err := checkError(data)
+	if err == nil {
+		t.Fatal("error expected")
+	}
+	if err.Error() != "-32001: Session expired." {
+		t.Errorf("invalid message %q", err.Error())
+	}
+	wrapped := fmt.Errorf("call: %w", err)
+	if !errors.Is(wrapped, ErrSessionExpired) {
+		t.Error("expected ErrSessionExpired")
+	}
+	if errors.Is(wrapped, ErrQuotaReached) {
+		t.Error("unexpected ErrQuotaReached")
+	}
+	var apiErr *ApiError
+	if !errors.As(wrapped, &apiErr) || len(apiErr.PositionalParameters) != 1 || apiErr.Plurality != 1 {
+		t.Errorf("invalid ApiError %+v", apiErr)
+	}
+	if checkError([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`)) != nil {
+		t.Error("unexpected error")
+	}
+}
@@ -1,7 +1,5 @@
 package webmail
 
-import "encoding/json"
-
 // FolderSubType - Folder sub-type enumeration.
 type FolderSubType string
 
@@ -92,303 +90,3 @@ type SharedMailbox struct {
 }
 
 type SharedMailboxList []SharedMailbox
-
-// Folder store manager class
-
-// FoldersClearToItemId - Remove all items in folder older then given item ID including. If folder is type of 'FMail' the items are moved to Delete Items or throw away if folder is Delete Items.
-//	itemId - the last item ID
-func (c *ClientConnection) FoldersClearToItemId(itemId KId) error {
-	params := struct {
-		ItemId KId `json:"itemId"`
-	}{itemId}
-	_, err := c.CallRaw("Folders.clearToItemId", params)
-	return err
-}
-
-// FoldersCreate - Create new folders
-//	folders - list of folders to create
-// Return
-//	errors - error message list
-//	result - list of ID of crated folders.
-func (c *ClientConnection) FoldersCreate(folders FolderList) (ErrorList, CreateResultList, error) {
-	params := struct {
-		Folders FolderList `json:"folders"`
-	}{folders}
-	data, err := c.CallRaw("Folders.create", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList        `json:"errors"`
-			Result CreateResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// FoldersGet - Obtain list of folders of currently logged user
-// Return
-//	list - list of folders
-func (c *ClientConnection) FoldersGet() (FolderList, error) {
-	data, err := c.CallRaw("Folders.get", nil)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List FolderList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// FoldersGetShared - Obtain list of folders which currently logged user can access
-// Return
-//	list - list of folders
-func (c *ClientConnection) FoldersGetShared(mailboxId KId) (FolderList, error) {
-	params := struct {
-		MailboxId KId `json:"mailboxId"`
-	}{mailboxId}
-	data, err := c.CallRaw("Folders.getShared", params)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List FolderList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// FoldersGetPublic - Obtain list of public folders which currently logged user can access
-// Return
-//	list - list of public folders
-func (c *ClientConnection) FoldersGetPublic() (FolderList, error) {
-	data, err := c.CallRaw("Folders.getPublic", nil)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List FolderList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// FoldersGetSubscribed - Obtain list of folders acording SubscriptionList.
-// Return
-//	list - list of folders
-func (c *ClientConnection) FoldersGetSubscribed() (SharedMailboxList, error) {
-	data, err := c.CallRaw("Folders.getSubscribed", nil)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List SharedMailboxList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// FoldersGetAutoCompleteContactsFolderId - Obtain ID of special folder for auto-complete contacts
-// Return
-//	folderId - ID of special folder
-func (c *ClientConnection) FoldersGetAutoCompleteContactsFolderId() (*KId, error) {
-	data, err := c.CallRaw("Folders.getAutoCompleteContactsFolderId", nil)
-	if err != nil {
-		return nil, err
-	}
-	folderId := struct {
-		Result struct {
-			FolderId KId `json:"folderId"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &folderId)
-	return &folderId.Result.FolderId, err
-}
-
-// FoldersGetSharedMailboxList - Obtain list of mailboxes with their folders which currently logged user can access
-// Return
-//	mailboxes - list of mailboxes with their folders
-func (c *ClientConnection) FoldersGetSharedMailboxList() (SharedMailboxList, error) {
-	data, err := c.CallRaw("Folders.getSharedMailboxList", nil)
-	if err != nil {
-		return nil, err
-	}
-	mailboxes := struct {
-		Result struct {
-			Mailboxes SharedMailboxList `json:"mailboxes"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &mailboxes)
-	return mailboxes.Result.Mailboxes, err
-}
-
-// FoldersMoveByType - Take a note that mail folders are moved recursively (the whole subtree)! Folders of other types (e.g. calendars) are not moved recursively.
-//	targetId - target folder ID
-//	ids - folder IDs
-// Return
-//	errors - error message list
-func (c *ClientConnection) FoldersMoveByType(targetId KId, ids KIdList) (ErrorList, error) {
-	params := struct {
-		TargetId KId     `json:"targetId"`
-		Ids      KIdList `json:"ids"`
-	}{targetId, ids}
-	data, err := c.CallRaw("Folders.moveByType", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// FoldersSet - Set folder properties
-//	folders - properties to save
-// Return
-//	errors - error message list
-func (c *ClientConnection) FoldersSet(folders FolderList) (ErrorList, error) {
-	params := struct {
-		Folders FolderList `json:"folders"`
-	}{folders}
-	data, err := c.CallRaw("Folders.set", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// FoldersRemove - Remove folder. Sub-folders are removed if recursive is true.
-//	ids - folder IDs
-//	recursive - remove sub-folders
-// Return
-//	errors - error message list
-func (c *ClientConnection) FoldersRemove(ids KIdList, recursive bool) (ErrorList, error) {
-	params := struct {
-		Ids       KIdList `json:"ids"`
-		Recursive bool    `json:"recursive"`
-	}{ids, recursive}
-	data, err := c.CallRaw("Folders.remove", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// FoldersRemoveByType - Take a note that mail folders are removed recursively! Folders of other types (e.g. calendars) are not removed recursively.
-//	ids - folder IDs
-// Return
-//	errors - error message list
-func (c *ClientConnection) FoldersRemoveByType(ids KIdList) (ErrorList, error) {
-	params := struct {
-		Ids KIdList `json:"ids"`
-	}{ids}
-	data, err := c.CallRaw("Folders.removeByType", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// FoldersGetPermissions - Get sharing permissions
-//	folderId - ID of folder
-// Return
-//	permissions - sharing settings
-func (c *ClientConnection) FoldersGetPermissions(folderId KId) (FolderPermissionList, error) {
-	params := struct {
-		FolderId KId `json:"folderId"`
-	}{folderId}
-	data, err := c.CallRaw("Folders.getPermissions", params)
-	if err != nil {
-		return nil, err
-	}
-	permissions := struct {
-		Result struct {
-			Permissions FolderPermissionList `json:"permissions"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &permissions)
-	return permissions.Result.Permissions, err
-}
-
-// FoldersSetPermissions - Set sharing permissions
-//	permissions - sharing settings
-//	folderId - ID of folder
-func (c *ClientConnection) FoldersSetPermissions(permissions FolderPermissionList, folderId KId, recursive bool) error {
-	params := struct {
-		Permissions FolderPermissionList `json:"permissions"`
-		FolderId    KId                  `json:"folderId"`
-		Recursive   bool                 `json:"recursive"`
-	}{permissions, folderId, recursive}
-	_, err := c.CallRaw("Folders.setPermissions", params)
-	return err
-}
-
-// FoldersGetSubscriptionList - Get list of subscribed folders
-func (c *ClientConnection) FoldersGetSubscriptionList() (KIdList, error) {
-	data, err := c.CallRaw("Folders.getSubscriptionList", nil)
-	if err != nil {
-		return nil, err
-	}
-	folderIds := struct {
-		Result struct {
-			FolderIds KIdList `json:"folderIds"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &folderIds)
-	return folderIds.Result.FolderIds, err
-}
-
-// FoldersSetSubscriptionList - Set list of subscribed folders
-func (c *ClientConnection) FoldersSetSubscriptionList(folderIds KIdList) error {
-	params := struct {
-		FolderIds KIdList `json:"folderIds"`
-	}{folderIds}
-	_, err := c.CallRaw("Folders.setSubscriptionList", params)
-	return err
-}
-
-// FoldersCopyAllMessages - Copies (or moves) all messages from the source folder to the destination
-//	sourceId - ID of the source folder
-//	destId - ID of the destionation folder
-//	doMove - if true move the messages instead of copy the
-func (c *ClientConnection) FoldersCopyAllMessages(sourceId KId, destId KId, doMove bool) error {
-	params := struct {
-		SourceId KId  `json:"sourceId"`
-		DestId   KId  `json:"destId"`
-		DoMove   bool `json:"doMove"`
-	}{sourceId, destId, doMove}
-	_, err := c.CallRaw("Folders.copyAllMessages", params)
-	return err
-}
@@ -0,0 +1,468 @@
+// Code generated by webmailgen from schema/folders.json. DO NOT EDIT.
+
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Folder store manager class
+
+// FoldersClearToItemId - Remove all items in folder older then given item ID including. If folder is type of 'FMail' the items are moved to Delete Items or throw away if folder is Delete Items.
+//
+//	itemId - the last item ID
+func (c *ClientConnection) FoldersClearToItemId(itemId KId) error {
+	return c.FoldersClearToItemIdContext(context.Background(), itemId)
+}
+
+// FoldersClearToItemIdContext - the same as FoldersClearToItemId, but the call is bound to ctx.
+func (c *ClientConnection) FoldersClearToItemIdContext(ctx context.Context, itemId KId) error {
+	params := struct {
+		ItemId KId `json:"itemId"`
+	}{itemId}
+	_, err := c.CallRawContext(ctx, "Folders.clearToItemId", params)
+	return err
+}
+
+// FoldersCreateResult - result of Folders.create
+type FoldersCreateResult struct {
+	Errors ErrorList        `json:"errors"` // error message list
+	Result CreateResultList `json:"result"` // list of ID of crated folders.
+}
+
+// FoldersCreate - Create new folders
+//
+//	folders - list of folders to create
+//
+// Return
+//
+//	errors - error message list
+//	result - list of ID of crated folders.
+func (c *ClientConnection) FoldersCreate(folders FolderList) (ErrorList, CreateResultList, error) {
+	return c.FoldersCreateContext(context.Background(), folders)
+}
+
+// FoldersCreateContext - the same as FoldersCreate, but the call is bound to ctx.
+func (c *ClientConnection) FoldersCreateContext(ctx context.Context, folders FolderList) (ErrorList, CreateResultList, error) {
+	params := struct {
+		Folders FolderList `json:"folders"`
+	}{folders}
+	data, err := c.CallRawContext(ctx, "Folders.create", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result FoldersCreateResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// FoldersGetResult - result of Folders.get
+type FoldersGetResult struct {
+	List FolderList `json:"list"` // list of folders
+}
+
+// FoldersGet - Obtain list of folders of currently logged user
+// Return
+//
+//	list - list of folders
+func (c *ClientConnection) FoldersGet() (FolderList, error) {
+	return c.FoldersGetContext(context.Background())
+}
+
+// FoldersGetContext - the same as FoldersGet, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetContext(ctx context.Context) (FolderList, error) {
+	data, err := c.CallRawContext(ctx, "Folders.get", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, err
+}
+
+// FoldersGetSharedResult - result of Folders.getShared
+type FoldersGetSharedResult struct {
+	List FolderList `json:"list"` // list of folders
+}
+
+// FoldersGetShared - Obtain list of folders which currently logged user can access
+//
+//	mailboxId
+//
+// Return
+//
+//	list - list of folders
+func (c *ClientConnection) FoldersGetShared(mailboxId KId) (FolderList, error) {
+	return c.FoldersGetSharedContext(context.Background(), mailboxId)
+}
+
+// FoldersGetSharedContext - the same as FoldersGetShared, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetSharedContext(ctx context.Context, mailboxId KId) (FolderList, error) {
+	params := struct {
+		MailboxId KId `json:"mailboxId"`
+	}{mailboxId}
+	data, err := c.CallRawContext(ctx, "Folders.getShared", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetSharedResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, err
+}
+
+// FoldersGetPublicResult - result of Folders.getPublic
+type FoldersGetPublicResult struct {
+	List FolderList `json:"list"` // list of public folders
+}
+
+// FoldersGetPublic - Obtain list of public folders which currently logged user can access
+// Return
+//
+//	list - list of public folders
+func (c *ClientConnection) FoldersGetPublic() (FolderList, error) {
+	return c.FoldersGetPublicContext(context.Background())
+}
+
+// FoldersGetPublicContext - the same as FoldersGetPublic, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetPublicContext(ctx context.Context) (FolderList, error) {
+	data, err := c.CallRawContext(ctx, "Folders.getPublic", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetPublicResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, err
+}
+
+// FoldersGetSubscribedResult - result of Folders.getSubscribed
+type FoldersGetSubscribedResult struct {
+	List SharedMailboxList `json:"list"` // list of folders
+}
+
+// FoldersGetSubscribed - Obtain list of folders acording SubscriptionList.
+// Return
+//
+//	list - list of folders
+func (c *ClientConnection) FoldersGetSubscribed() (SharedMailboxList, error) {
+	return c.FoldersGetSubscribedContext(context.Background())
+}
+
+// FoldersGetSubscribedContext - the same as FoldersGetSubscribed, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetSubscribedContext(ctx context.Context) (SharedMailboxList, error) {
+	data, err := c.CallRawContext(ctx, "Folders.getSubscribed", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetSubscribedResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, err
+}
+
+// FoldersGetAutoCompleteContactsFolderIdResult - result of Folders.getAutoCompleteContactsFolderId
+type FoldersGetAutoCompleteContactsFolderIdResult struct {
+	FolderId KId `json:"folderId"` // ID of special folder
+}
+
+// FoldersGetAutoCompleteContactsFolderId - Obtain ID of special folder for auto-complete contacts
+// Return
+//
+//	folderId - ID of special folder
+func (c *ClientConnection) FoldersGetAutoCompleteContactsFolderId() (*KId, error) {
+	return c.FoldersGetAutoCompleteContactsFolderIdContext(context.Background())
+}
+
+// FoldersGetAutoCompleteContactsFolderIdContext - the same as FoldersGetAutoCompleteContactsFolderId, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetAutoCompleteContactsFolderIdContext(ctx context.Context) (*KId, error) {
+	data, err := c.CallRawContext(ctx, "Folders.getAutoCompleteContactsFolderId", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetAutoCompleteContactsFolderIdResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.FolderId, err
+}
+
+// FoldersGetSharedMailboxListResult - result of Folders.getSharedMailboxList
+type FoldersGetSharedMailboxListResult struct {
+	Mailboxes SharedMailboxList `json:"mailboxes"` // list of mailboxes with their folders
+}
+
+// FoldersGetSharedMailboxList - Obtain list of mailboxes with their folders which currently logged user can access
+// Return
+//
+//	mailboxes - list of mailboxes with their folders
+func (c *ClientConnection) FoldersGetSharedMailboxList() (SharedMailboxList, error) {
+	return c.FoldersGetSharedMailboxListContext(context.Background())
+}
+
+// FoldersGetSharedMailboxListContext - the same as FoldersGetSharedMailboxList, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetSharedMailboxListContext(ctx context.Context) (SharedMailboxList, error) {
+	data, err := c.CallRawContext(ctx, "Folders.getSharedMailboxList", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetSharedMailboxListResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Mailboxes, err
+}
+
+// FoldersMoveByTypeResult - result of Folders.moveByType
+type FoldersMoveByTypeResult struct {
+	Errors ErrorList `json:"errors"` // error message list
+}
+
+// FoldersMoveByType - Take a note that mail folders are moved recursively (the whole subtree)! Folders of other types (e.g. calendars) are not moved recursively.
+//
+//	targetId - target folder ID
+//	ids - folder IDs
+//
+// Return
+//
+//	errors - error message list
+func (c *ClientConnection) FoldersMoveByType(targetId KId, ids KIdList) (ErrorList, error) {
+	return c.FoldersMoveByTypeContext(context.Background(), targetId, ids)
+}
+
+// FoldersMoveByTypeContext - the same as FoldersMoveByType, but the call is bound to ctx.
+func (c *ClientConnection) FoldersMoveByTypeContext(ctx context.Context, targetId KId, ids KIdList) (ErrorList, error) {
+	params := struct {
+		TargetId KId     `json:"targetId"`
+		Ids      KIdList `json:"ids"`
+	}{targetId, ids}
+	data, err := c.CallRawContext(ctx, "Folders.moveByType", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersMoveByTypeResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
+
+// FoldersSetResult - result of Folders.set
+type FoldersSetResult struct {
+	Errors ErrorList `json:"errors"` // error message list
+}
+
+// FoldersSet - Set folder properties
+//
+//	folders - properties to save
+//
+// Return
+//
+//	errors - error message list
+func (c *ClientConnection) FoldersSet(folders FolderList) (ErrorList, error) {
+	return c.FoldersSetContext(context.Background(), folders)
+}
+
+// FoldersSetContext - the same as FoldersSet, but the call is bound to ctx.
+func (c *ClientConnection) FoldersSetContext(ctx context.Context, folders FolderList) (ErrorList, error) {
+	params := struct {
+		Folders FolderList `json:"folders"`
+	}{folders}
+	data, err := c.CallRawContext(ctx, "Folders.set", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersSetResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
+
+// FoldersRemoveResult - result of Folders.remove
+type FoldersRemoveResult struct {
+	Errors ErrorList `json:"errors"` // error message list
+}
+
+// FoldersRemove - Remove folder. Sub-folders are removed if recursive is true.
+//
+//	ids - folder IDs
+//	recursive - remove sub-folders
+//
+// Return
+//
+//	errors - error message list
+func (c *ClientConnection) FoldersRemove(ids KIdList, recursive bool) (ErrorList, error) {
+	return c.FoldersRemoveContext(context.Background(), ids, recursive)
+}
+
+// FoldersRemoveContext - the same as FoldersRemove, but the call is bound to ctx.
+func (c *ClientConnection) FoldersRemoveContext(ctx context.Context, ids KIdList, recursive bool) (ErrorList, error) {
+	params := struct {
+		Ids       KIdList `json:"ids"`
+		Recursive bool    `json:"recursive"`
+	}{ids, recursive}
+	data, err := c.CallRawContext(ctx, "Folders.remove", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersRemoveResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
+
+// FoldersRemoveByTypeResult - result of Folders.removeByType
+type FoldersRemoveByTypeResult struct {
+	Errors ErrorList `json:"errors"` // error message list
+}
+
+// FoldersRemoveByType - Take a note that mail folders are removed recursively! Folders of other types (e.g. calendars) are not removed recursively.
+//
+//	ids - folder IDs
+//
+// Return
+//
+//	errors - error message list
+func (c *ClientConnection) FoldersRemoveByType(ids KIdList) (ErrorList, error) {
+	return c.FoldersRemoveByTypeContext(context.Background(), ids)
+}
+
+// FoldersRemoveByTypeContext - the same as FoldersRemoveByType, but the call is bound to ctx.
+func (c *ClientConnection) FoldersRemoveByTypeContext(ctx context.Context, ids KIdList) (ErrorList, error) {
+	params := struct {
+		Ids KIdList `json:"ids"`
+	}{ids}
+	data, err := c.CallRawContext(ctx, "Folders.removeByType", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersRemoveByTypeResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
+
+// FoldersGetPermissionsResult - result of Folders.getPermissions
+type FoldersGetPermissionsResult struct {
+	Permissions FolderPermissionList `json:"permissions"` // sharing settings
+}
+
+// FoldersGetPermissions - Get sharing permissions
+//
+//	folderId - ID of folder
+//
+// Return
+//
+//	permissions - sharing settings
+func (c *ClientConnection) FoldersGetPermissions(folderId KId) (FolderPermissionList, error) {
+	return c.FoldersGetPermissionsContext(context.Background(), folderId)
+}
+
+// FoldersGetPermissionsContext - the same as FoldersGetPermissions, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetPermissionsContext(ctx context.Context, folderId KId) (FolderPermissionList, error) {
+	params := struct {
+		FolderId KId `json:"folderId"`
+	}{folderId}
+	data, err := c.CallRawContext(ctx, "Folders.getPermissions", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetPermissionsResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Permissions, err
+}
+
+// FoldersSetPermissions - Set sharing permissions
+//
+//	permissions - sharing settings
+//	folderId - ID of folder
+//	recursive
+func (c *ClientConnection) FoldersSetPermissions(permissions FolderPermissionList, folderId KId, recursive bool) error {
+	return c.FoldersSetPermissionsContext(context.Background(), permissions, folderId, recursive)
+}
+
+// FoldersSetPermissionsContext - the same as FoldersSetPermissions, but the call is bound to ctx.
+func (c *ClientConnection) FoldersSetPermissionsContext(ctx context.Context, permissions FolderPermissionList, folderId KId, recursive bool) error {
+	params := struct {
+		Permissions FolderPermissionList `json:"permissions"`
+		FolderId    KId                  `json:"folderId"`
+		Recursive   bool                 `json:"recursive"`
+	}{permissions, folderId, recursive}
+	_, err := c.CallRawContext(ctx, "Folders.setPermissions", params)
+	return err
+}
+
+// FoldersGetSubscriptionListResult - result of Folders.getSubscriptionList
+type FoldersGetSubscriptionListResult struct {
+	FolderIds KIdList `json:"folderIds"`
+}
+
+// FoldersGetSubscriptionList - Get list of subscribed folders
+// Return
+//
+//	folderIds
+func (c *ClientConnection) FoldersGetSubscriptionList() (KIdList, error) {
+	return c.FoldersGetSubscriptionListContext(context.Background())
+}
+
+// FoldersGetSubscriptionListContext - the same as FoldersGetSubscriptionList, but the call is bound to ctx.
+func (c *ClientConnection) FoldersGetSubscriptionListContext(ctx context.Context) (KIdList, error) {
+	data, err := c.CallRawContext(ctx, "Folders.getSubscriptionList", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result FoldersGetSubscriptionListResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.FolderIds, err
+}
+
+// FoldersSetSubscriptionList - Set list of subscribed folders
+//
+//	folderIds
+func (c *ClientConnection) FoldersSetSubscriptionList(folderIds KIdList) error {
+	return c.FoldersSetSubscriptionListContext(context.Background(), folderIds)
+}
+
+// FoldersSetSubscriptionListContext - the same as FoldersSetSubscriptionList, but the call is bound to ctx.
+func (c *ClientConnection) FoldersSetSubscriptionListContext(ctx context.Context, folderIds KIdList) error {
+	params := struct {
+		FolderIds KIdList `json:"folderIds"`
+	}{folderIds}
+	_, err := c.CallRawContext(ctx, "Folders.setSubscriptionList", params)
+	return err
+}
+
+// FoldersCopyAllMessages - Copies (or moves) all messages from the source folder to the destination
+//
+//	sourceId - ID of the source folder
+//	destId - ID of the destionation folder
+//	doMove - if true move the messages instead of copy the
+func (c *ClientConnection) FoldersCopyAllMessages(sourceId KId, destId KId, doMove bool) error {
+	return c.FoldersCopyAllMessagesContext(context.Background(), sourceId, destId, doMove)
+}
+
+// FoldersCopyAllMessagesContext - the same as FoldersCopyAllMessages, but the call is bound to ctx.
+func (c *ClientConnection) FoldersCopyAllMessagesContext(ctx context.Context, sourceId KId, destId KId, doMove bool) error {
+	params := struct {
+		SourceId KId  `json:"sourceId"`
+		DestId   KId  `json:"destId"`
+		DoMove   bool `json:"doMove"`
+	}{sourceId, destId, doMove}
+	_, err := c.CallRawContext(ctx, "Folders.copyAllMessages", params)
+	return err
+}
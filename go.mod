@@ -2,4 +2,7 @@ module github.com/igiant/webmail
 
 go 1.16
 
-require gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+require (
+	golang.org/x/text v0.13.0
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
+)
@@ -0,0 +1,260 @@
+package webmail
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HTMLToText converts the HTML to plain text for previews and quoting. The paragraphs, line breaks, lists and table rows
+// are kept, blockquotes are quoted by '>', the links are followed by their URLs and the images are replaced by their alt texts.
+func HTMLToText(content string) string {
+	w := &textWriter{}
+	var lists []int // counters of ordered lists, -1 for unordered ones
+	var links []string
+	var linkStarts []int
+	skip, skipDepth := "", 0
+	for _, token := range tokenizeHTML(content) {
+		if skip != "" {
+			if token.data == skip && token.typ == htmlStartTag && !token.selfClosing {
+				skipDepth++
+			} else if token.data == skip && token.typ == htmlEndTag {
+				skipDepth--
+			}
+			if skipDepth == 0 {
+				skip = ""
+			}
+			continue
+		}
+		switch token.typ {
+		case htmlText:
+			w.text(token.data)
+		case htmlStartTag:
+			switch name := token.data; {
+			case droppedElements[name]:
+				if !token.selfClosing {
+					skip, skipDepth = name, 1
+				}
+			case name == "br":
+				w.lineBreak()
+			case name == "hr":
+				w.breakLines(1)
+				w.write("----")
+				w.breakLines(1)
+			case name == "p" || headingElements[name] || name == "table" || name == "dl":
+				w.breakLines(2)
+			case name == "blockquote":
+				w.breakLines(2)
+				w.quote++
+			case name == "pre":
+				w.breakLines(1)
+				w.pre++
+			case name == "ul" || name == "ol":
+				w.breakLines(1)
+				counter := -1
+				if name == "ol" {
+					counter = 1
+					if start, err := strconv.Atoi(attributeValue(token, "start")); err == nil {
+						counter = start
+					}
+				}
+				lists = append(lists, counter)
+			case name == "li":
+				w.breakLines(1)
+				marker := "* "
+				if n := len(lists); n > 0 {
+					if lists[n-1] >= 0 {
+						marker = strconv.Itoa(lists[n-1]) + ". "
+						lists[n-1]++
+					}
+					marker = strings.Repeat("  ", n-1) + marker
+				}
+				w.write(marker)
+			case name == "tr":
+				w.breakLines(1)
+				w.cells = 0
+			case name == "td" || name == "th":
+				if w.cells > 0 {
+					w.write("\t")
+				}
+				w.cells++
+			case name == "img":
+				if alt := strings.TrimSpace(attributeValue(token, "alt")); alt != "" {
+					w.text("[" + alt + "]")
+				}
+			case name == "a":
+				links = append(links, attributeValue(token, "href"))
+				linkStarts = append(linkStarts, w.b.Len())
+			case blockElements[name]:
+				w.breakLines(1)
+			}
+		case htmlEndTag:
+			switch name := token.data; {
+			case name == "p" || headingElements[name] || name == "table" || name == "dl":
+				w.breakLines(2)
+			case name == "blockquote":
+				if w.quote > 0 {
+					w.breakLines(2)
+					w.quote--
+				}
+			case name == "pre":
+				if w.pre > 0 {
+					w.breakLines(1)
+					w.pre--
+				}
+			case name == "ul" || name == "ol":
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+				}
+				w.breakLines(1)
+			case name == "a":
+				if n := len(links); n > 0 {
+					w.linkURL(links[n-1], w.b.String()[linkStarts[n-1]:])
+					links, linkStarts = links[:n-1], linkStarts[:n-1]
+				}
+			case blockElements[name] || name == "li" || name == "tr":
+				w.breakLines(1)
+			}
+		}
+	}
+	return w.String()
+}
+
+var headingElements = setOf("h1", "h2", "h3", "h4", "h5", "h6")
+
+// blockElements - elements on separate lines, besides the ones handled separately by HTMLToText
+var blockElements = setOf("address", "article", "aside", "caption", "center", "dd", "details", "div", "dt", "figcaption",
+	"figure", "footer", "form", "header", "main", "nav", "section", "summary")
+
+func attributeValue(token htmlToken, name string) string {
+	for _, attribute := range token.attrs {
+		if attribute.name == name {
+			return attribute.value
+		}
+	}
+	return ""
+}
+
+// textWriter - builder of the plain text collapsing the white space like browsers do
+type textWriter struct {
+	b        strings.Builder
+	quote    int  // depth of blockquotes
+	quoted   int  // depth of blockquotes of the last written line
+	pre      int  // depth of preformatted elements
+	newlines int  // line ends to be written before the next text
+	space    bool // space to be written before the next text
+	line     bool // something was written to the current line
+	cells    int  // number of cells of the table row
+}
+
+// text writes the text, the white space is collapsed outside pre elements
+func (w *textWriter) text(s string) {
+	for _, r := range s {
+		switch {
+		case w.pre > 0 && r == '\n':
+			w.lineBreak()
+		case w.pre > 0 && r == '\r':
+		case w.pre > 0 || r == '\u00a0':
+			w.writeRune(r)
+		case unicode.IsSpace(r):
+			w.space = w.line
+		default:
+			w.writeRune(r)
+		}
+	}
+}
+
+// write writes s as it is
+func (w *textWriter) write(s string) {
+	for _, r := range s {
+		w.writeRune(r)
+	}
+}
+
+func (w *textWriter) writeRune(r rune) {
+	if r == '\u00a0' {
+		r = ' '
+	}
+	if w.b.Len() > 0 {
+		for i := 0; i < w.newlines; i++ {
+			w.b.WriteString("\n")
+			// the empty line between the quotes of different depth belongs to the outer one
+			if depth := minInt(w.quote, w.quoted); i < w.newlines-1 && depth > 0 {
+				w.b.WriteString(strings.Repeat(">", depth))
+			}
+		}
+	}
+	w.newlines = 0
+	if !w.line {
+		if w.quote > 0 {
+			w.b.WriteString(strings.Repeat(">", w.quote) + " ")
+		}
+		w.space = false
+	}
+	if w.space {
+		w.b.WriteByte(' ')
+		w.space = false
+	}
+	w.b.WriteRune(r)
+	w.line, w.quoted = true, w.quote
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// lineBreak ends the line, the empty lines are kept
+func (w *textWriter) lineBreak() {
+	w.newlines++
+	w.line, w.space = false, false
+}
+
+// breakLines ensures that the next text starts after n line ends
+func (w *textWriter) breakLines(n int) {
+	if w.b.Len() == 0 {
+		return
+	}
+	if n > w.newlines {
+		w.newlines = n
+	}
+	w.line, w.space = false, false
+}
+
+// linkURL writes the URL of the link unless it is its text
+func (w *textWriter) linkURL(href, text string) {
+	scheme, rest := splitURLScheme(href)
+	if scheme != "http" && scheme != "https" && scheme != "mailto" {
+		return
+	}
+	if scheme == "mailto" {
+		href = rest
+	}
+	if strings.Contains(text, href) {
+		return
+	}
+	w.space = w.line
+	w.write("<" + href + ">")
+}
+
+// String returns the text without trailing spaces of lines and at most one empty line in a row
+func (w *textWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	var result []string
+	empty := 0
+	for _, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if strings.Trim(line, "> ") == "" {
+			empty++
+			if empty > 1 {
+				continue
+			}
+		} else {
+			empty = 0
+		}
+		result = append(result, line)
+	}
+	return strings.Trim(strings.Join(result, "\n"), "\n")
+}
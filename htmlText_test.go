@@ -0,0 +1,45 @@
+package webmail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{"<p>Hello,\n   <b>world</b>!</p><p>Second</p>", "Hello, world!\n\nSecond"},
+		{"<html><head><title>T</title><style>p {}</style></head><body>Text</body></html>", "Text"},
+		{"a<br>b<br><br>c", "a\nb\n\nc"},
+		{"<div>one</div><div>two</div>", "one\ntwo"},
+		{"<ul><li>first</li><li>second<ol start=3><li>third</li></ol></li></ul>", "* first\n* second\n  3. third"},
+		{"<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>", "A\tB\n1\t2"},
+		{`<a href="https://example.com">site</a> and <a href="https://example.com">https://example.com</a>`,
+			"site <https://example.com> and https://example.com"},
+		{`<a href="mailto:bob@example.com">bob@example.com</a>`, "bob@example.com"},
+		{"<p>Hi</p><blockquote><p>quoted</p><p>more</p><blockquote>deep</blockquote></blockquote>after",
+			"Hi\n\n> quoted\n>\n> more\n>\n>> deep\n\nafter"},
+		{"<pre>  a\n    b</pre>c", "  a\n    b\nc"},
+		{`x&nbsp;&nbsp;y &lt;z&gt; <img src="a.png" alt="Logo"><script>alert(1)</script>`, "x  y <z> [Logo]"},
+		{"<h1>Title</h1>text<hr>end", "Title\n\ntext\n----\nend"},
+	}
+	for _, test := range tests {
+		if got := HTMLToText(test.html); got != test.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", test.html, got, test.want)
+		}
+	}
+}
+
+func TestReply_QuotesHTMLAsText(t *testing.T) {
+	original := Mail{
+		Subject:          "Report",
+		From:             EMail{Address: "alice@example.com"},
+		DisplayableParts: DisplayableMimePartList{{ContentType: CtTextHtml, Content: "<p>See <b>numbers</b></p><p>Alice</p>"}},
+	}
+	mail := (&ClientConnection{}).Reply(original).Build()
+	if text, ok := partContent(mail, CtTextPlain); !ok || !strings.HasSuffix(text, "wrote:\n> See numbers\n> \n> Alice") {
+		t.Errorf("got %q", text)
+	}
+}
@@ -1,7 +1,5 @@
 package webmail
 
-import "encoding/json"
-
 // SyncFolder - Class with methods for integration
 type SyncFolder struct {
 	Id           KId             `json:"id"`           // [READ-ONLY] global identification
@@ -18,81 +16,3 @@ type SyncFolder struct {
 }
 
 type SyncFolderList []SyncFolder
-
-// Class with methods for integration
-
-// IntegrationGetASyncFolderList - Obtain list of folders of currently logged user
-// Return
-//	list - list of folders
-func (c *ClientConnection) IntegrationGetASyncFolderList() (SyncFolderList, error) {
-	data, err := c.CallRaw("Integration.getASyncFolderList", nil)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List SyncFolderList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// IntegrationSetASyncFolderList - Set folder properties
-//	folders - properties to save
-// Return
-//	errors - error message list
-func (c *ClientConnection) IntegrationSetASyncFolderList(folders SyncFolderList) (ErrorList, error) {
-	params := struct {
-		Folders SyncFolderList `json:"folders"`
-	}{folders}
-	data, err := c.CallRaw("Integration.setASyncFolderList", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// IntegrationGetIPhoneSyncFolderList - Obtain list of folders of currently logged user (task and calendars only)
-// Return
-//	list - list of folders
-func (c *ClientConnection) IntegrationGetIPhoneSyncFolderList() (SyncFolderList, error) {
-	data, err := c.CallRaw("Integration.getIPhoneSyncFolderList", nil)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List SyncFolderList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// IntegrationSetIPhoneSyncFolderList - Set folder properties (task and calendars only)
-//	folders - properties to save
-// Return
-//	errors - error message list
-func (c *ClientConnection) IntegrationSetIPhoneSyncFolderList(folders SyncFolderList) (ErrorList, error) {
-	params := struct {
-		Folders SyncFolderList `json:"folders"`
-	}{folders}
-	data, err := c.CallRaw("Integration.setIPhoneSyncFolderList", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
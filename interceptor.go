@@ -0,0 +1,61 @@
+package webmail
+
+import (
+	"context"
+	"net/http"
+)
+
+// Invoker performs the call of method with params and returns the raw response
+type Invoker func(ctx context.Context, method string, params interface{}) ([]byte, error)
+
+// Interceptor - middleware around every call made by CallRaw and the API methods.
+// It may change the method, params or ctx before calling next, change the result,
+// or return a result without calling next at all. A batch is intercepted as one call of BatchMethod.
+type Interceptor func(ctx context.Context, method string, params interface{}, next Invoker) ([]byte, error)
+
+// WithInterceptors adds interceptors to the config, they are called in the given order
+// before the interceptors added by ClientConnection.Use
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(c *Config) {
+		c.interceptors = append(c.interceptors[:len(c.interceptors):len(c.interceptors)], interceptors...)
+	}
+}
+
+// Use adds interceptors to the connection, they are called in the given order after the interceptors of the config
+func (c *ClientConnection) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors[:len(c.interceptors):len(c.interceptors)], interceptors...)
+}
+
+func (c *ClientConnection) chain(invoker Invoker) Invoker {
+	c.mu.RLock()
+	own := c.interceptors
+	c.mu.RUnlock()
+	interceptors := append(c.Config.interceptors[:len(c.Config.interceptors):len(c.Config.interceptors)], own...)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, params interface{}) ([]byte, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+	return invoker
+}
+
+type headerKey struct{}
+
+// WithRequestHeader returns a copy of ctx with HTTP header added to the requests made with it,
+// e.g. by an interceptor before calling next
+func WithRequestHeader(ctx context.Context, key, value string) context.Context {
+	header := http.Header{}
+	if parent, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		header = parent.Clone()
+	}
+	header.Add(key, value)
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+func requestHeader(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+	return header
+}
@@ -0,0 +1,53 @@
+package webmail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientConnection_Use(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Audit") != "1" {
+			t.Error("header is not injected")
+		}
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"userDetails":{"loginName":"server"}}}`)
+	}))
+	defer server.Close()
+	var order []string
+	logger := func(name string) Interceptor {
+		return func(ctx context.Context, method string, params interface{}, next Invoker) ([]byte, error) {
+			order = append(order, name+" "+method)
+			return next(ctx, method, params)
+		}
+	}
+	conf := NewConfig("", WithInterceptors(logger("config")))
+	conf.url = server.URL
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Use(logger("conn"), func(ctx context.Context, method string, params interface{}, next Invoker) ([]byte, error) {
+		if method == "Session.canUserChangePassword" {
+			return []byte(`{"jsonrpc":"2.0","id":1,"result":{"isEligible":true}}`), nil
+		}
+		return next(WithRequestHeader(ctx, "X-Audit", "1"), method, params)
+	})
+	user, err := conn.SessionWhoAmI()
+	if err != nil || user.LoginName != "server" {
+		t.Errorf("unexpected result %v, %v", user, err)
+	}
+	ok, err := conn.SessionCanUserChangePassword()
+	if err != nil || !ok {
+		t.Errorf("fake response is not returned: %v", err)
+	}
+	expected := []string{
+		"config Session.whoAmI", "conn Session.whoAmI",
+		"config Session.canUserChangePassword", "conn Session.canUserChangePassword",
+	}
+	if fmt.Sprint(order) != fmt.Sprint(expected) {
+		t.Errorf("unexpected order %v", order)
+	}
+}
@@ -0,0 +1,440 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultPageSize - number of items fetched by one call of iterator unless WithPageSize is used
+const DefaultPageSize = 100
+
+// ErrResultSetChanged - the items found by the query were changed while the iterator was reading them,
+// so some items may be skipped or returned twice. Iterate again to get the consistent result.
+var ErrResultSetChanged = errors.New("result set changed during iteration")
+
+// IteratorOption - setting of the iterator over pages of the list
+type IteratorOption func(*iteratorOptions)
+
+type iteratorOptions struct {
+	pageSize int
+	prefetch bool
+}
+
+// WithPageSize sets the number of items fetched by one call
+func WithPageSize(size int) IteratorOption {
+	return func(o *iteratorOptions) {
+		if size > 0 {
+			o.pageSize = size
+		}
+	}
+}
+
+// WithPrefetch enables fetching of the next page concurrently while the current page is read
+func WithPrefetch() IteratorOption {
+	return func(o *iteratorOptions) {
+		o.prefetch = true
+	}
+}
+
+// pageFetcher returns the items of the page and the number of all items found by the query
+type pageFetcher func(ctx context.Context, query SearchQuery) ([]interface{}, int, error)
+
+type pageResult struct {
+	items []interface{}
+	total int
+	err   error
+}
+
+// iterator - reads the pages of the list lazily, it is embedded by the typed iterators
+type iterator struct {
+	ctx       context.Context
+	fetch     pageFetcher
+	key       func(item interface{}) string // identifies the item to detect shifting
+	options   iteratorOptions
+	query     SearchQuery
+	remaining int // number of items up to the limit of the query, Unlimited if it is not limited
+	total     int // number of found items, -1 before the first page
+	page      []interface{}
+	index     int
+	item      interface{}
+	seen      map[string]bool
+	next      chan pageResult // prefetched page
+	done      bool
+	err       error
+}
+
+func newIterator(ctx context.Context, query SearchQuery, fetch pageFetcher, key func(interface{}) string, options []IteratorOption) iterator {
+	o := iteratorOptions{pageSize: DefaultPageSize}
+	for _, option := range options {
+		option(&o)
+	}
+	remaining := query.Limit
+	if remaining <= 0 {
+		remaining = Unlimited
+	}
+	return iterator{
+		ctx:       ctx,
+		fetch:     fetch,
+		key:       key,
+		options:   o,
+		query:     addMissedParametersToSearchQuery(query),
+		remaining: remaining,
+		total:     -1,
+		seen:      map[string]bool{},
+	}
+}
+
+// Next advances to the next item, which is then available through Item.
+// It returns false when there are no more items or an error occurred, see Err.
+func (it *iterator) Next() bool {
+	for it.err == nil && it.index >= len(it.page) {
+		if it.done {
+			it.item = nil
+			return false
+		}
+		it.load()
+	}
+	if it.err != nil {
+		it.item = nil
+		return false
+	}
+	it.item = it.page[it.index]
+	it.index++
+	if key := it.key(it.item); key != "" {
+		if it.seen[key] {
+			it.item = nil
+			it.err = fmt.Errorf("%w: item %s returned twice", ErrResultSetChanged, key)
+			return false
+		}
+		it.seen[key] = true
+	}
+	return true
+}
+
+// Err returns the error which stopped the iteration, nil if all items were read
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Total returns the number of items found by the query, -1 before the first call of Next
+func (it *iterator) Total() int {
+	return it.total
+}
+
+// load reads the next page
+func (it *iterator) load() {
+	var result pageResult
+	if it.next != nil {
+		result = <-it.next
+		it.next = nil
+	} else {
+		result = it.fetchPage(it.pageQuery())
+	}
+	if result.err != nil {
+		it.err = result.err
+		return
+	}
+	if it.total >= 0 && result.total != it.total {
+		it.err = fmt.Errorf("%w: total number of items %d changed to %d", ErrResultSetChanged, it.total, result.total)
+		return
+	}
+	it.total = result.total
+	items := result.items
+	if it.remaining != Unlimited && len(items) > it.remaining {
+		items = items[:it.remaining]
+	}
+	it.page = items
+	it.index = 0
+	it.query.Start += len(items)
+	if it.remaining != Unlimited {
+		it.remaining -= len(items)
+	}
+	if len(items) == 0 || it.query.Start >= it.total || it.remaining == 0 {
+		it.done = true
+		return
+	}
+	if it.options.prefetch {
+		it.next = make(chan pageResult, 1)
+		go func(next chan<- pageResult, query SearchQuery) {
+			next <- it.fetchPage(query)
+		}(it.next, it.pageQuery())
+	}
+}
+
+// pageQuery returns the query of the next page
+func (it *iterator) pageQuery() SearchQuery {
+	query := it.query
+	query.Limit = it.options.pageSize
+	if it.remaining != Unlimited && it.remaining < query.Limit {
+		query.Limit = it.remaining
+	}
+	return query
+}
+
+func (it *iterator) fetchPage(query SearchQuery) pageResult {
+	items, total, err := it.fetch(it.ctx, query)
+	return pageResult{items: items, total: total, err: err}
+}
+
+// MailIterator - iterator over e-mails, see MailsGetIterator
+type MailIterator struct {
+	iterator
+}
+
+// Item returns the current e-mail
+func (it *MailIterator) Item() Mail {
+	item, _ := it.item.(Mail)
+	return item
+}
+
+// MailsGetIterator returns the iterator over e-mails found by MailsGet.
+//	folderIds - list of global identifiers of folders to be listed.
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) MailsGetIterator(folderIds KIdList, query SearchQuery, options ...IteratorOption) *MailIterator {
+	return c.MailsGetIteratorContext(context.Background(), folderIds, query, options...)
+}
+
+// MailsGetIteratorContext - the same as MailsGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) MailsGetIteratorContext(ctx context.Context, folderIds KIdList, query SearchQuery, options ...IteratorOption) *MailIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.MailsGetContext(ctx, folderIds, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Mail).Id) }
+	return &MailIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// ContactIterator - iterator over contacts, see ContactsGetIterator
+type ContactIterator struct {
+	iterator
+}
+
+// Item returns the current contact
+func (it *ContactIterator) Item() Contact {
+	item, _ := it.item.(Contact)
+	return item
+}
+
+// ContactsGetIterator returns the iterator over contacts found by ContactsGet.
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) ContactsGetIterator(folderIds KIdList, query SearchQuery, options ...IteratorOption) *ContactIterator {
+	return c.ContactsGetIteratorContext(context.Background(), folderIds, query, options...)
+}
+
+// ContactsGetIteratorContext - the same as ContactsGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) ContactsGetIteratorContext(ctx context.Context, folderIds KIdList, query SearchQuery, options ...IteratorOption) *ContactIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.ContactsGetContext(ctx, folderIds, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Contact).Id) }
+	return &ContactIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// ResourceIterator - iterator over resources, see ContactsGetResourcesIterator
+type ResourceIterator struct {
+	iterator
+}
+
+// Item returns the current resource
+func (it *ResourceIterator) Item() Resource {
+	item, _ := it.item.(Resource)
+	return item
+}
+
+// ContactsGetResourcesIterator returns the iterator over resources found by ContactsGetResources.
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) ContactsGetResourcesIterator(query SearchQuery, options ...IteratorOption) *ResourceIterator {
+	return c.ContactsGetResourcesIteratorContext(context.Background(), query, options...)
+}
+
+// ContactsGetResourcesIteratorContext - the same as ContactsGetResourcesIterator, but the calls are bound to ctx.
+func (c *ClientConnection) ContactsGetResourcesIteratorContext(ctx context.Context, query SearchQuery, options ...IteratorOption) *ResourceIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.ContactsGetResourcesContext(ctx, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return item.(Resource).Address }
+	return &ResourceIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// EventIterator - iterator over events, see EventsGetIterator
+type EventIterator struct {
+	iterator
+}
+
+// Item returns the current event
+func (it *EventIterator) Item() Event {
+	item, _ := it.item.(Event)
+	return item
+}
+
+// EventsGetIterator returns the iterator over events found by EventsGet.
+//	ids - list of global identifiers of events to be listed
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) EventsGetIterator(ids KIdList, query SearchQuery, options ...IteratorOption) *EventIterator {
+	return c.EventsGetIteratorContext(context.Background(), ids, query, options...)
+}
+
+// EventsGetIteratorContext - the same as EventsGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) EventsGetIteratorContext(ctx context.Context, ids KIdList, query SearchQuery, options ...IteratorOption) *EventIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.EventsGetContext(ctx, ids, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Event).Id) }
+	return &EventIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// OccurrenceIterator - iterator over occurrences, see OccurrencesGetIterator
+type OccurrenceIterator struct {
+	iterator
+}
+
+// Item returns the current occurrence
+func (it *OccurrenceIterator) Item() Occurrence {
+	item, _ := it.item.(Occurrence)
+	return item
+}
+
+// OccurrencesGetIterator returns the iterator over occurrences found by OccurrencesGet.
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) OccurrencesGetIterator(folderIds KIdList, query SearchQuery, options ...IteratorOption) *OccurrenceIterator {
+	return c.OccurrencesGetIteratorContext(context.Background(), folderIds, query, options...)
+}
+
+// OccurrencesGetIteratorContext - the same as OccurrencesGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) OccurrencesGetIteratorContext(ctx context.Context, folderIds KIdList, query SearchQuery, options ...IteratorOption) *OccurrenceIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.OccurrencesGetContext(ctx, folderIds, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Occurrence).Id) }
+	return &OccurrenceIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// TaskIterator - iterator over tasks, see TasksGetIterator
+type TaskIterator struct {
+	iterator
+}
+
+// Item returns the current task
+func (it *TaskIterator) Item() Task {
+	item, _ := it.item.(Task)
+	return item
+}
+
+// TasksGetIterator returns the iterator over tasks found by TasksGet.
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) TasksGetIterator(folderIds KIdList, query SearchQuery, options ...IteratorOption) *TaskIterator {
+	return c.TasksGetIteratorContext(context.Background(), folderIds, query, options...)
+}
+
+// TasksGetIteratorContext - the same as TasksGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) TasksGetIteratorContext(ctx context.Context, folderIds KIdList, query SearchQuery, options ...IteratorOption) *TaskIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.TasksGetContext(ctx, folderIds, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Task).Id) }
+	return &TaskIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// NoteIterator - iterator over notes, see NotesGetIterator
+type NoteIterator struct {
+	iterator
+}
+
+// Item returns the current note
+func (it *NoteIterator) Item() Note {
+	item, _ := it.item.(Note)
+	return item
+}
+
+// NotesGetIterator returns the iterator over notes found by NotesGet.
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) NotesGetIterator(folderIds KIdList, query SearchQuery, options ...IteratorOption) *NoteIterator {
+	return c.NotesGetIteratorContext(context.Background(), folderIds, query, options...)
+}
+
+// NotesGetIteratorContext - the same as NotesGetIterator, but the calls are bound to ctx.
+func (c *ClientConnection) NotesGetIteratorContext(ctx context.Context, folderIds KIdList, query SearchQuery, options ...IteratorOption) *NoteIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.NotesGetContext(ctx, folderIds, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return string(item.(Note).Id) }
+	return &NoteIterator{newIterator(ctx, query, fetch, key, options)}
+}
+
+// MobileDeviceIterator - iterator over mobile devices, see SessionGetMobileDeviceListIterator
+type MobileDeviceIterator struct {
+	iterator
+}
+
+// Item returns the current mobile device
+func (it *MobileDeviceIterator) Item() MobileDevice {
+	item, _ := it.item.(MobileDevice)
+	return item
+}
+
+// SessionGetMobileDeviceListIterator returns the iterator over mobile devices found by SessionGetMobileDeviceList.
+//	query - query attributes, Start and Limit limit all the items of iteration
+//	options - page size and prefetching
+func (c *ClientConnection) SessionGetMobileDeviceListIterator(query SearchQuery, options ...IteratorOption) *MobileDeviceIterator {
+	return c.SessionGetMobileDeviceListIteratorContext(context.Background(), query, options...)
+}
+
+// SessionGetMobileDeviceListIteratorContext - the same as SessionGetMobileDeviceListIterator, but the calls are bound to ctx.
+func (c *ClientConnection) SessionGetMobileDeviceListIteratorContext(ctx context.Context, query SearchQuery, options ...IteratorOption) *MobileDeviceIterator {
+	fetch := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		list, total, err := c.SessionGetMobileDeviceListContext(ctx, query)
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, total, err
+	}
+	key := func(item interface{}) string { return item.(MobileDevice).DeviceId }
+	return &MobileDeviceIterator{newIterator(ctx, query, fetch, key, options)}
+}
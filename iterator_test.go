@@ -0,0 +1,122 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// fakePages returns the fetcher of pages of items and the list of queries it received
+func fakePages(items []string) (pageFetcher, *[]SearchQuery, *sync.Mutex) {
+	var queries []SearchQuery
+	mu := &sync.Mutex{}
+	fetch := func(_ context.Context, query SearchQuery) ([]interface{}, int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		queries = append(queries, query)
+		var page []interface{}
+		for i := query.Start; i < len(items) && len(page) < query.Limit; i++ {
+			page = append(page, items[i])
+		}
+		return page, len(items), nil
+	}
+	return fetch, &queries, mu
+}
+
+func stringKey(item interface{}) string {
+	return item.(string)
+}
+
+func makeItems(count int) []string {
+	items := make([]string, count)
+	for i := range items {
+		items[i] = fmt.Sprint("item", i)
+	}
+	return items
+}
+
+func TestIterator(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		items := makeItems(25)
+		fetch, queries, mu := fakePages(items)
+		options := []IteratorOption{WithPageSize(10)}
+		if prefetch {
+			options = append(options, WithPrefetch())
+		}
+		it := newIterator(context.Background(), SearchQuery{}, fetch, stringKey, options)
+		var got []string
+		for it.Next() {
+			got = append(got, it.item.(string))
+		}
+		if it.Err() != nil {
+			t.Fatal(it.Err())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(items) || it.Total() != 25 {
+			t.Errorf("prefetch %v: got %v, total %d", prefetch, got, it.Total())
+		}
+		mu.Lock()
+		if len(*queries) != 3 || (*queries)[2].Start != 20 || (*queries)[2].Limit != 10 {
+			t.Errorf("prefetch %v: queries %+v", prefetch, *queries)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestIterator_Limit(t *testing.T) {
+	fetch, queries, _ := fakePages(makeItems(25))
+	it := newIterator(context.Background(), SearchQuery{Start: 5, Limit: 12}, fetch, stringKey, []IteratorOption{WithPageSize(10)})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil || count != 12 {
+		t.Errorf("got %d items, error %v", count, it.Err())
+	}
+	if len(*queries) != 2 || (*queries)[0].Start != 5 || (*queries)[1].Start != 15 || (*queries)[1].Limit != 2 {
+		t.Errorf("queries %+v", *queries)
+	}
+}
+
+func TestIterator_Shift(t *testing.T) {
+	items := makeItems(15)
+	fetch, _, _ := fakePages(items)
+	grow := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		page, total, err := fetch(ctx, query)
+		if query.Start > 0 {
+			total++
+		}
+		return page, total, err
+	}
+	it := newIterator(context.Background(), SearchQuery{}, grow, stringKey, []IteratorOption{WithPageSize(10)})
+	for it.Next() {
+	}
+	if !errors.Is(it.Err(), ErrResultSetChanged) {
+		t.Errorf("changed total: got %v", it.Err())
+	}
+
+	// an item inserted at the beginning moves the last item of the first page to the second one
+	shift := func(ctx context.Context, query SearchQuery) ([]interface{}, int, error) {
+		if query.Start > 0 {
+			query.Start--
+		}
+		return fetch(ctx, query)
+	}
+	it = newIterator(context.Background(), SearchQuery{}, shift, stringKey, []IteratorOption{WithPageSize(10)})
+	for it.Next() {
+	}
+	if !errors.Is(it.Err(), ErrResultSetChanged) {
+		t.Errorf("repeated item: got %v", it.Err())
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	fail := func(context.Context, SearchQuery) ([]interface{}, int, error) {
+		return nil, 0, ErrAccessDenied
+	}
+	it := newIterator(context.Background(), SearchQuery{}, fail, stringKey, nil)
+	if it.Next() || !errors.Is(it.Err(), ErrAccessDenied) {
+		t.Errorf("got %v", it.Err())
+	}
+}
@@ -0,0 +1,113 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Redacted - replacement of the secret values in logs
+const Redacted = "***"
+
+// SecretFields - names of the members of params which hold secrets and are masked by Redact
+var SecretFields = map[string]bool{
+	"password":        true, // Session.login, Session.wipeMobileDevice, Certificates.*
+	"currentPassword": true, // Session.setPassword
+	"newPassword":     true, // Session.setPassword, Certificates.*
+	"oldPassword":     true, // Certificates.*
+	"loginPassword":   true, // Certificates.*
+	"token":           true, // request member and Session.login result
+}
+
+// CallRecord - debug information about a call
+type CallRecord struct {
+	Method       string        // method name, BatchMethod, UploadMethod or DownloadMethod
+	ID           int           // request id, 0 for batches, uploads and downloads
+	Duration     time.Duration // time of the HTTP round-trip
+	RequestSize  int           // size of request body in bytes, size of the file for uploads
+	ResponseSize int           // size of response body in bytes
+	ErrorCode    int           // code of ApiError, 0 if there is none
+	Err          error         // error of the call
+	Request      []byte        // request body with secrets masked by Redact
+	Header       http.Header   // request headers with X-Token masked
+}
+
+// Logger - receiver of debug records of all calls
+type Logger interface {
+	LogCall(record CallRecord)
+}
+
+// LoggerFunc - adapter to use a function as Logger
+type LoggerFunc func(record CallRecord)
+
+// LogCall calls f(record)
+func (f LoggerFunc) LogCall(record CallRecord) {
+	f(record)
+}
+
+// WithLogger sets the logger of the calls, nil disables logging
+func WithLogger(logger Logger) Option {
+	return func(c *Config) {
+		c.logger = logger
+	}
+}
+
+// Redact returns JSON data with the values of SecretFields masked at any depth.
+// Data which is not valid JSON is returned as is.
+func Redact(data []byte) []byte {
+	var v interface{}
+	if json.Unmarshal(data, &v) != nil {
+		return data
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if _, ok := item.(string); ok && SecretFields[key] {
+				value[key] = Redacted
+			} else {
+				value[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header.Get("X-Token") != "" {
+		header.Set("X-Token", Redacted)
+	}
+	return header
+}
+
+// logCall completes the record and sends it to the logger of the config, if any
+func (c *ClientConnection) logCall(ctx context.Context, record CallRecord, token *string, request []byte) {
+	logger := c.Config.logger
+	if logger == nil {
+		return
+	}
+	if request != nil {
+		record.RequestSize = len(request)
+		record.Request = Redact(request)
+	}
+	record.Header = redactHeader(newRequestHeader(ctx, token))
+	var apiErr *ApiError
+	if errors.As(record.Err, &apiErr) {
+		record.ErrorCode = apiErr.Code
+	}
+	logger.LogCall(record)
+}
@@ -0,0 +1,47 @@
+package webmail
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":2,"error":{"code":4002,"message":"Failed"}}`)
+	}))
+	defer server.Close()
+	var records []CallRecord
+	conf := NewConfig("", WithLogger(LoggerFunc(func(record CallRecord) {
+		records = append(records, record)
+	})))
+	conf.url = server.URL
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetToken("secret-token")
+	if err = conn.SessionSetPassword("old-secret", "new-secret"); err == nil {
+		t.Error("error expected")
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	if record.Method != "Session.setPassword" || record.ID != 1 || record.ErrorCode != ErrorCodeChangePswFailed {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if record.RequestSize == 0 || record.ResponseSize == 0 {
+		t.Error("sizes are not set")
+	}
+	for _, secret := range []string{"secret-token", "old-secret", "new-secret"} {
+		if bytes.Contains(record.Request, []byte(secret)) {
+			t.Errorf("%s is not redacted in %s", secret, record.Request)
+		}
+	}
+	if record.Header.Get("X-Token") != Redacted {
+		t.Error("X-Token is not redacted")
+	}
+}
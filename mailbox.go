@@ -0,0 +1,521 @@
+package webmail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MailboxFormat - format of the local copy of mail folders, see ExportFolder.
+// The <name> is the folder name with '/', '\', '%' and NUL escaped like in URLs. The names of Maildir subdirectories,
+// "." and ".." and the names equal to another one ignoring case have also some of their first characters escaped.
+type MailboxFormat int
+
+const (
+	Mbox    MailboxFormat = iota // file <name>.mbox per folder (mboxrd), subfolders in directory <name>
+	Maildir                      // directory <name> with cur, new and tmp per folder, subfolders are nested directories
+)
+
+const mboxExtension = ".mbox"
+
+// subdirectories of Maildir, the nested folders of these names are escaped
+var maildirSubdirs = map[string]bool{"cur": true, "new": true, "tmp": true}
+
+// ExportFolder writes the mails of the folder to the local directory, the folder is stored under its name.
+// The seen, flagged and answered flags are kept in Status and X-Status headers of mbox or in the names of Maildir files.
+// The root folder is exported as the directory of its mail subfolders.
+//	folderId - global identification of the mail folder
+//	dir - local directory, created if it does not exist
+//	format - Mbox or Maildir
+//	recursive - export also the subfolders, preserving the folder tree
+func (c *ClientConnection) ExportFolder(folderId KId, dir string, format MailboxFormat, recursive bool) error {
+	return c.ExportFolderContext(context.Background(), folderId, dir, format, recursive)
+}
+
+// ExportFolderContext - the same as ExportFolder, but the calls are bound to ctx.
+func (c *ClientConnection) ExportFolderContext(ctx context.Context, folderId KId, dir string, format MailboxFormat, recursive bool) error {
+	folders, err := c.FoldersGetContext(ctx)
+	if err != nil {
+		return err
+	}
+	exporter := &mailboxExporter{ctx: ctx, conn: c, format: format, recursive: recursive, folders: folders}
+	for _, folder := range folders {
+		if folder.Id != folderId {
+			continue
+		}
+		if err = os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		switch folder.Type {
+		case FRoot:
+			return exporter.exportChildren(folder.Id, dir)
+		case FMail:
+			return exporter.exportFolder(folder, dir)
+		}
+		return fmt.Errorf("folder %s is not a mail folder", folder.Name)
+	}
+	return ErrNoSuchFolder
+}
+
+// ImportFolder recreates the folder exported by ExportFolder with its mails and subfolders under the parent folder.
+// A folder with the same name under the parent is reused. If path is a directory of exported folders
+// (not a Maildir), its folders are imported directly under the parent.
+//	path - mbox file or Maildir directory
+//	parentId - global identification of the parent folder
+//	format - Mbox or Maildir
+// Return
+//	id - global identification of the imported folder, parentId for the directory of exported folders
+func (c *ClientConnection) ImportFolder(path string, parentId KId, format MailboxFormat) (KId, error) {
+	return c.ImportFolderContext(context.Background(), path, parentId, format)
+}
+
+// ImportFolderContext - the same as ImportFolder, but the calls are bound to ctx.
+func (c *ClientConnection) ImportFolderContext(ctx context.Context, path string, parentId KId, format MailboxFormat) (KId, error) {
+	folders, err := c.FoldersGetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	importer := &mailboxImporter{ctx: ctx, conn: c, format: format, folders: folders}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() && (format == Mbox || !isMaildir(path)) {
+		return parentId, importer.importChildren(path, parentId)
+	}
+	return importer.importFolder(path, folderName(strings.TrimSuffix(filepath.Base(path), mboxExtension)), parentId)
+}
+
+// mailboxExporter - state of ExportFolder
+type mailboxExporter struct {
+	ctx       context.Context
+	conn      *ClientConnection
+	format    MailboxFormat
+	recursive bool
+	folders   FolderList
+	count     int                        // number of Maildir files for unique names
+	used      map[string]map[string]bool // file names in lower case used in the directories
+}
+
+func (e *mailboxExporter) exportFolder(folder Folder, dir string) error {
+	path := filepath.Join(dir, e.localFolderName(dir, folder.Name))
+	var err error
+	if e.format == Mbox {
+		err = e.writeMbox(folder.Id, path+mboxExtension)
+	} else {
+		err = e.writeMaildir(folder.Id, path)
+	}
+	if err != nil || !e.recursive {
+		return err
+	}
+	return e.exportChildren(folder.Id, path)
+}
+
+// exportChildren exports the mail subfolders of the parent to dir, which is created if there are any
+func (e *mailboxExporter) exportChildren(parentId KId, dir string) error {
+	for _, folder := range e.folders {
+		if folder.ParentId != parentId || folder.Type != FMail {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		if err := e.exportFolder(folder, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachMessage calls fn for each mail of the folder read with all its parts and its MIME message
+func (e *mailboxExporter) eachMessage(folderId KId, fn func(m Mail, message []byte) error) error {
+	var ids KIdList
+	flush := func() error {
+		if len(ids) == 0 {
+			return nil
+		}
+		errs, mails, err := e.conn.MailsGetByIdContext(e.ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(errs) > 0 {
+			return errs[0].ApiError()
+		}
+		ids = ids[:0]
+		for _, m := range mails {
+			buffer := &bytes.Buffer{}
+			if err = e.conn.ExportEMLContext(e.ctx, buffer, m); err != nil {
+				return err
+			}
+			// local mailboxes use the line ends of Unix
+			if err = fn(m, bytes.Replace(buffer.Bytes(), []byte("\r\n"), []byte("\n"), -1)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	it := e.conn.MailsGetIteratorContext(e.ctx, KIdList{folderId}, SearchQuery{Fields: StringList{string(MailFieldId)}})
+	for it.Next() {
+		ids = append(ids, it.Item().Id)
+		if len(ids) == DefaultPageSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (e *mailboxExporter) writeMbox(folderId KId, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	err = e.eachMessage(folderId, func(m Mail, message []byte) error {
+		return writeMboxMessage(writer, m, message)
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeMboxMessage writes the message with the From line and the flags, the lines starting with From are quoted
+func writeMboxMessage(w *bufio.Writer, m Mail, message []byte) error {
+	sender := m.From.Address
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	date := m.ReceiveDate
+	if date.IsZero() {
+		date = m.SendDate
+	}
+	t, err := date.Time()
+	if err != nil || t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	status, xStatus := "O", ""
+	if m.IsSeen {
+		status = "RO"
+	}
+	if m.IsAnswered {
+		xStatus += "A"
+	}
+	if m.IsFlagged {
+		xStatus += "F"
+	}
+	if _, err = fmt.Fprintf(w, "From %s %s\nStatus: %s\n", sender, t.UTC().Format(time.ANSIC), status); err != nil {
+		return err
+	}
+	if xStatus != "" {
+		if _, err = fmt.Fprintf(w, "X-Status: %s\n", xStatus); err != nil {
+			return err
+		}
+	}
+	lines := bytes.Split(bytes.TrimSuffix(message, []byte("\n")), []byte("\n"))
+	for _, line := range lines {
+		if isMboxFromLine(line) {
+			if err = w.WriteByte('>'); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(line); err != nil {
+			return err
+		}
+		if err = w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte('\n')
+}
+
+// isMboxFromLine returns true for the line starting with From optionally quoted by '>'
+func isMboxFromLine(line []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From "))
+}
+
+func (e *mailboxExporter) writeMaildir(folderId KId, dir string) error {
+	for sub := range maildirSubdirs {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return err
+		}
+	}
+	return e.eachMessage(folderId, func(m Mail, message []byte) error {
+		e.count++
+		name := fmt.Sprintf("%d.%d_%d.webmail", time.Now().Unix(), os.Getpid(), e.count)
+		tmp := filepath.Join(dir, "tmp", name)
+		if err := ioutil.WriteFile(tmp, message, 0600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, filepath.Join(dir, "cur", name+":2,"+maildirFlags(m)))
+	})
+}
+
+// maildirFlags returns the info of Maildir file name, the flags are sorted
+func maildirFlags(m Mail) string {
+	flags := ""
+	if m.IsFlagged {
+		flags += "F"
+	}
+	if m.IsAnswered {
+		flags += "R"
+	}
+	if m.IsSeen {
+		flags += "S"
+	}
+	return flags
+}
+
+// localFolderName returns the folder name usable as file name, unique in the directory regardless of case
+func (e *mailboxExporter) localFolderName(dir, name string) string {
+	if e.used == nil {
+		e.used = map[string]map[string]bool{}
+	}
+	used := e.used[dir]
+	if used == nil {
+		used = map[string]bool{}
+		if e.format == Maildir {
+			for sub := range maildirSubdirs {
+				used[sub] = true
+			}
+		}
+		e.used[dir] = used
+	}
+	for n := 0; ; n++ {
+		local := escapeFolderName(name, n)
+		if n > len(name) {
+			local += fmt.Sprintf("~%d", n-len(name))
+		}
+		key := strings.ToLower(local)
+		if local == "" || local == "." || local == ".." || used[key] || e.format == Mbox && used[key+mboxExtension] {
+			continue
+		}
+		used[key] = true
+		if e.format == Mbox {
+			used[key+mboxExtension] = true
+		}
+		return local
+	}
+}
+
+// escapeFolderName escapes the characters unusable in file names and the first n bytes of the name like in URLs
+func escapeFolderName(name string, n int) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if i < n || c == '/' || c == '\\' || c == '%' || c == 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// folderName returns the name of the folder exported to the file of the local name
+func folderName(local string) string {
+	if name, err := url.PathUnescape(local); err == nil {
+		return name
+	}
+	return local
+}
+
+// mailboxImporter - state of ImportFolder
+type mailboxImporter struct {
+	ctx     context.Context
+	conn    *ClientConnection
+	format  MailboxFormat
+	folders FolderList
+}
+
+func (im *mailboxImporter) importFolder(path, name string, parentId KId) (KId, error) {
+	id, err := im.folder(name, parentId)
+	if err != nil {
+		return "", err
+	}
+	children := path
+	if im.format == Mbox {
+		if err = im.readMbox(path, id); err != nil {
+			return "", err
+		}
+		children = strings.TrimSuffix(path, mboxExtension)
+		if info, err := os.Stat(children); err != nil || !info.IsDir() {
+			return id, nil
+		}
+	} else if isMaildir(path) {
+		if err = im.readMaildir(path, id); err != nil {
+			return "", err
+		}
+	}
+	return id, im.importChildren(children, id)
+}
+
+// importChildren imports the exported folders in dir under the parent
+func (im *mailboxImporter) importChildren(dir string, parentId KId) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	for _, entry := range entries {
+		name, path := entry.Name(), filepath.Join(dir, entry.Name())
+		switch {
+		case im.format == Mbox && !entry.IsDir() && strings.HasSuffix(name, mboxExtension):
+			_, err = im.importFolder(path, folderName(strings.TrimSuffix(name, mboxExtension)), parentId)
+		case im.format == Mbox && entry.IsDir() && !names[name+mboxExtension]:
+			// folder without mails, which has subfolders
+			var id KId
+			if id, err = im.folder(folderName(name), parentId); err == nil {
+				err = im.importChildren(path, id)
+			}
+		case im.format == Maildir && entry.IsDir() && !maildirSubdirs[name]:
+			_, err = im.importFolder(path, folderName(name), parentId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// folder returns the mail folder of the name under the parent, it is created if it does not exist
+func (im *mailboxImporter) folder(name string, parentId KId) (KId, error) {
+	for _, folder := range im.folders {
+		if folder.ParentId == parentId && folder.Name == name && folder.Type == FMail {
+			return folder.Id, nil
+		}
+	}
+	folder := Folder{ParentId: parentId, Name: name, Type: FMail}
+	errs, result, err := im.conn.FoldersCreateContext(im.ctx, FolderList{folder})
+	if err != nil {
+		return "", err
+	}
+	if len(errs) > 0 {
+		return "", errs[0].ApiError()
+	}
+	if len(result) == 0 {
+		return "", errors.New("no result of folder creation")
+	}
+	folder.Id = result[0].Id
+	im.folders = append(im.folders, folder)
+	return folder.Id, nil
+}
+
+func (im *mailboxImporter) readMbox(path string, folderId KId) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	reader := bufio.NewReader(file)
+	var message []byte
+	started := false
+	flush := func() error {
+		if !started {
+			return nil
+		}
+		// the empty line separating the messages
+		message = bytes.TrimSuffix(message, []byte("\n"))
+		return im.importMessage(bytes.NewReader(message), folderId, func(m *Mail, header mail.Header) {
+			m.IsSeen = strings.Contains(header.Get("Status"), "R")
+			m.IsAnswered = strings.Contains(header.Get("X-Status"), "A")
+			m.IsFlagged = strings.Contains(header.Get("X-Status"), "F")
+		})
+	}
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			switch {
+			case bytes.HasPrefix(line, []byte("From ")):
+				if err := flush(); err != nil {
+					return err
+				}
+				message, started = nil, true
+			case started:
+				if isMboxFromLine(line) {
+					line = line[1:]
+				}
+				message = append(message, line...)
+			}
+		}
+		if err == io.EOF {
+			return flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (im *mailboxImporter) readMaildir(dir string, folderId KId) error {
+	for _, sub := range []string{"cur", "new"} {
+		entries, err := ioutil.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			flags := ""
+			if i := strings.LastIndex(entry.Name(), ":2,"); i >= 0 {
+				flags = entry.Name()[i+3:]
+			}
+			if err = im.importFile(filepath.Join(dir, sub, entry.Name()), folderId, flags); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (im *mailboxImporter) importFile(path string, folderId KId, flags string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	return im.importMessage(file, folderId, func(m *Mail, _ mail.Header) {
+		m.IsSeen = strings.Contains(flags, "S")
+		m.IsAnswered = strings.Contains(flags, "R")
+		m.IsFlagged = strings.Contains(flags, "F")
+	})
+}
+
+// importMessage creates the mail from the MIME message, setFlags sets its flags kept by the mailbox
+func (im *mailboxImporter) importMessage(r io.Reader, folderId KId, setFlags func(m *Mail, header mail.Header)) error {
+	m, attachments, header, err := parseEML(r)
+	if err != nil {
+		return err
+	}
+	m.FolderId = folderId
+	setFlags(m, header)
+	_, err = im.conn.createMailWithAttachments(im.ctx, *m, attachments)
+	return err
+}
+
+// isMaildir returns true if the directory contains the subdirectory cur
+func isMaildir(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, "cur"))
+	return err == nil && info.IsDir()
+}
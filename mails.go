@@ -1,7 +1,5 @@
 package webmail
 
-import "encoding/json"
-
 type EMail struct {
 	Name      string `json:"name"`
 	Address   string `json:"address"`
@@ -25,8 +23,8 @@ type AttachmentList []Attachment
 type DisplayableContentType string
 
 const (
-	ctTextPlain DisplayableContentType = "ctTextPlain"
-	ctTextHtml  DisplayableContentType = "ctTextHtml"
+	CtTextPlain DisplayableContentType = "ctTextPlain"
+	CtTextHtml  DisplayableContentType = "ctTextHtml"
 )
 
 type DisplayableMimePart struct {
@@ -40,9 +38,9 @@ type DisplayableMimePartList []DisplayableMimePart
 type MimeHeaderType string
 
 const (
-	mhMessageID       MimeHeaderType = "mhMessageID"       // [READ-ONLY]
-	mhInReplayTo      MimeHeaderType = "mhInReplayTo"      // The contents of this field identify previous correspondence which this message answers. It contents an original 'Message-ID' value. rfc0822
-	mhResentMessageID MimeHeaderType = "mhResentMessageID" // The contents of this field identify a forwarded message. It contains an original 'Message-ID' value. rfc0822
+	MhMessageID       MimeHeaderType = "mhMessageID"       // [READ-ONLY]
+	MhInReplayTo      MimeHeaderType = "mhInReplayTo"      // The contents of this field identify previous correspondence which this message answers. It contents an original 'Message-ID' value. rfc0822
+	MhResentMessageID MimeHeaderType = "mhResentMessageID" // The contents of this field identify a forwarded message. It contains an original 'Message-ID' value. rfc0822
 )
 
 type MimeHeader struct {
@@ -117,228 +115,3 @@ type Mail struct {
 }
 
 type MailList []Mail
-
-// Mail store manager class
-
-// MailsGet - Get a list of e-mails.
-//	folderIds - list of global identifiers of folders to be listed.
-//	query - query attributes and limits
-// Return
-//	list - all found e-mails
-//  totalItems - number of mails found if there is no limit
-func (c *ClientConnection) MailsGet(folderIds KIdList, query SearchQuery) (MailList, int, error) {
-	query = addMissedParametersToSearchQuery(query)
-	params := struct {
-		FolderIds KIdList     `json:"folderIds"`
-		Query     SearchQuery `json:"query"`
-	}{folderIds, query}
-	data, err := c.CallRaw("Mails.get", params)
-	if err != nil {
-		return nil, 0, err
-	}
-	list := struct {
-		Result struct {
-			List       MailList `json:"list"`
-			TotalItems int      `json:"totalItems"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, list.Result.TotalItems, err
-}
-
-// MailsGetPageWithId - Get a list of e-mails.
-//	folderIds - list of global identifiers of folders to be listed
-//	query - query attributes and limits. Mind that offset is not used
-//	id - global identifier of requested email
-// Return
-//	list - all found e-mails
-//  totalItems - number of mails found if there is no limit
-func (c *ClientConnection) MailsGetPageWithId(folderIds KIdList, query SearchQuery, id KId) (MailList, int, int, error) {
-	query = addMissedParametersToSearchQuery(query)
-	params := struct {
-		FolderIds KIdList     `json:"folderIds"`
-		Query     SearchQuery `json:"query"`
-		Id        KId         `json:"id"`
-	}{folderIds, query, id}
-	data, err := c.CallRaw("Mails.getPageWithId", params)
-	if err != nil {
-		return nil, 0, 0, err
-	}
-	list := struct {
-		Result struct {
-			List       MailList `json:"list"`
-			Start      int      `json:"start"`
-			TotalItems int      `json:"totalItems"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, list.Result.Start, list.Result.TotalItems, err
-}
-
-// MailsGetById - Get one particular email. All members of struct Mail are filed in response.
-//	ids - global identifiers of requested emails
-// Return
-//	errors - list of email that failed to obtain
-//	result - found emails
-func (c *ClientConnection) MailsGetById(ids KIdList) (ErrorList, MailList, error) {
-	params := struct {
-		Ids KIdList `json:"ids"`
-	}{ids}
-	data, err := c.CallRaw("Mails.getById", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-			Result MailList  `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// MailsCreate - ErrorCodeSendingFailed - Failed to send email and failed to create mail.
-//	mails - new mails.
-// Return
-//	errors - error message list
-//	result - list of ID of crated mails.
-func (c *ClientConnection) MailsCreate(mails MailList) (ErrorList, CreateResultList, error) {
-	params := struct {
-		Mails MailList `json:"mails"`
-	}{mails}
-	data, err := c.CallRaw("Mails.create", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList        `json:"errors"`
-			Result CreateResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// MailsRemove - Remove a list of mails.
-//	ids - list of global identifiers of mails to be removed
-// Return
-//	errors - list of mails that failed to remove
-func (c *ClientConnection) MailsRemove(ids KIdList) (ErrorList, error) {
-	params := struct {
-		Ids KIdList `json:"ids"`
-	}{ids}
-	data, err := c.CallRaw("Mails.remove", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// MailsSet - ErrorCodeSendingFailed - Failed to send email and failed to update mail.
-//	mails - modifications of mails.
-// Return
-//	errors - error message list
-func (c *ClientConnection) MailsSet(mails MailList) (ErrorList, SetResultList, error) {
-	params := struct {
-		Mails MailList `json:"mails"`
-	}{mails}
-	data, err := c.CallRaw("Mails.set", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList     `json:"errors"`
-			Result SetResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// MailsSetAllSeen - Set all e-mail in folder as seen.
-//	folderId - target folder
-func (c *ClientConnection) MailsSetAllSeen(folderId KId) error {
-	params := struct {
-		FolderId KId `json:"folderId"`
-	}{folderId}
-	_, err := c.CallRaw("Mails.setAllSeen", params)
-	return err
-}
-
-// MailsCopy - Copy existing e-mails to folder
-//	ids - list of global identifiers of mails to be copied
-//	folder - target folder
-// Return
-//	errors - error message list
-func (c *ClientConnection) MailsCopy(ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
-	params := struct {
-		Ids    KIdList `json:"ids"`
-		Folder KId     `json:"folder"`
-	}{ids, folder}
-	data, err := c.CallRaw("Mails.copy", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList        `json:"errors"`
-			Result CreateResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// MailsMove - Move existing e-mails to folder
-//	ids - list of global identifiers of e-mails to be moved
-//	folder - target folder
-// Return
-//	errors - error message list
-func (c *ClientConnection) MailsMove(ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
-	params := struct {
-		Ids    KIdList `json:"ids"`
-		Folder KId     `json:"folder"`
-	}{ids, folder}
-	data, err := c.CallRaw("Mails.move", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList        `json:"errors"`
-			Result CreateResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// MailsExportAttachments - Export attachments from mail and pack them into zip.
-//	attachmentIds - list of global identifiers of attachments. All attachments must be from the same e-mail.
-// Return
-//	fileDownload - description of output file
-func (c *ClientConnection) MailsExportAttachments(attachmentIds KIdList) (*Download, error) {
-	params := struct {
-		AttachmentIds KIdList `json:"attachmentIds"`
-	}{attachmentIds}
-	data, err := c.CallRaw("Mails.exportAttachments", params)
-	if err != nil {
-		return nil, err
-	}
-	fileDownload := struct {
-		Result struct {
-			FileDownload Download `json:"fileDownload"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &fileDownload)
-	return &fileDownload.Result.FileDownload, err
-}
@@ -0,0 +1,342 @@
+// Code generated by webmailgen from schema/mails.json. DO NOT EDIT.
+
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Mail store manager class
+
+// MailsGetResult - result of Mails.get
+type MailsGetResult struct {
+	List       MailList `json:"list"`       // all found e-mails
+	TotalItems int      `json:"totalItems"` // number of mails found if there is no limit
+}
+
+// MailsGet - Get a list of e-mails.
+//
+//	folderIds - list of global identifiers of folders to be listed.
+//	query - query attributes and limits
+//
+// Return
+//
+//	list - all found e-mails
+//	totalItems - number of mails found if there is no limit
+func (c *ClientConnection) MailsGet(folderIds KIdList, query SearchQuery) (MailList, int, error) {
+	return c.MailsGetContext(context.Background(), folderIds, query)
+}
+
+// MailsGetContext - the same as MailsGet, but the call is bound to ctx.
+func (c *ClientConnection) MailsGetContext(ctx context.Context, folderIds KIdList, query SearchQuery) (MailList, int, error) {
+	query = addMissedParametersToSearchQuery(query)
+	params := struct {
+		FolderIds KIdList     `json:"folderIds"`
+		Query     SearchQuery `json:"query"`
+	}{folderIds, query}
+	data, err := c.CallRawContext(ctx, "Mails.get", params)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := struct {
+		Result MailsGetResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, result.Result.TotalItems, err
+}
+
+// MailsGetPageWithIdResult - result of Mails.getPageWithId
+type MailsGetPageWithIdResult struct {
+	List       MailList `json:"list"` // all found e-mails
+	Start      int      `json:"start"`
+	TotalItems int      `json:"totalItems"` // number of mails found if there is no limit
+}
+
+// MailsGetPageWithId - Get a list of e-mails.
+//
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes and limits. Mind that offset is not used
+//	id - global identifier of requested email
+//
+// Return
+//
+//	list - all found e-mails
+//	start
+//	totalItems - number of mails found if there is no limit
+func (c *ClientConnection) MailsGetPageWithId(folderIds KIdList, query SearchQuery, id KId) (MailList, int, int, error) {
+	return c.MailsGetPageWithIdContext(context.Background(), folderIds, query, id)
+}
+
+// MailsGetPageWithIdContext - the same as MailsGetPageWithId, but the call is bound to ctx.
+func (c *ClientConnection) MailsGetPageWithIdContext(ctx context.Context, folderIds KIdList, query SearchQuery, id KId) (MailList, int, int, error) {
+	query = addMissedParametersToSearchQuery(query)
+	params := struct {
+		FolderIds KIdList     `json:"folderIds"`
+		Query     SearchQuery `json:"query"`
+		Id        KId         `json:"id"`
+	}{folderIds, query, id}
+	data, err := c.CallRawContext(ctx, "Mails.getPageWithId", params)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	result := struct {
+		Result MailsGetPageWithIdResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, result.Result.Start, result.Result.TotalItems, err
+}
+
+// MailsGetByIdResult - result of Mails.getById
+type MailsGetByIdResult struct {
+	Errors ErrorList `json:"errors"` // list of email that failed to obtain
+	Result MailList  `json:"result"` // found emails
+}
+
+// MailsGetById - Get one particular email. All members of struct Mail are filed in response.
+//
+//	ids - global identifiers of requested emails
+//
+// Return
+//
+//	errors - list of email that failed to obtain
+//	result - found emails
+func (c *ClientConnection) MailsGetById(ids KIdList) (ErrorList, MailList, error) {
+	return c.MailsGetByIdContext(context.Background(), ids)
+}
+
+// MailsGetByIdContext - the same as MailsGetById, but the call is bound to ctx.
+func (c *ClientConnection) MailsGetByIdContext(ctx context.Context, ids KIdList) (ErrorList, MailList, error) {
+	params := struct {
+		Ids KIdList `json:"ids"`
+	}{ids}
+	data, err := c.CallRawContext(ctx, "Mails.getById", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result MailsGetByIdResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// MailsCreateResult - result of Mails.create
+type MailsCreateResult struct {
+	Errors ErrorList        `json:"errors"` // error message list
+	Result CreateResultList `json:"result"` // list of ID of crated mails.
+}
+
+// MailsCreate - ErrorCodeSendingFailed - Failed to send email and failed to create mail.
+//
+//	mails - new mails.
+//
+// Return
+//
+//	errors - error message list
+//	result - list of ID of crated mails.
+func (c *ClientConnection) MailsCreate(mails MailList) (ErrorList, CreateResultList, error) {
+	return c.MailsCreateContext(context.Background(), mails)
+}
+
+// MailsCreateContext - the same as MailsCreate, but the call is bound to ctx.
+func (c *ClientConnection) MailsCreateContext(ctx context.Context, mails MailList) (ErrorList, CreateResultList, error) {
+	params := struct {
+		Mails MailList `json:"mails"`
+	}{mails}
+	data, err := c.CallRawContext(ctx, "Mails.create", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result MailsCreateResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// MailsRemoveResult - result of Mails.remove
+type MailsRemoveResult struct {
+	Errors ErrorList `json:"errors"` // list of mails that failed to remove
+}
+
+// MailsRemove - Remove a list of mails.
+//
+//	ids - list of global identifiers of mails to be removed
+//
+// Return
+//
+//	errors - list of mails that failed to remove
+func (c *ClientConnection) MailsRemove(ids KIdList) (ErrorList, error) {
+	return c.MailsRemoveContext(context.Background(), ids)
+}
+
+// MailsRemoveContext - the same as MailsRemove, but the call is bound to ctx.
+func (c *ClientConnection) MailsRemoveContext(ctx context.Context, ids KIdList) (ErrorList, error) {
+	params := struct {
+		Ids KIdList `json:"ids"`
+	}{ids}
+	data, err := c.CallRawContext(ctx, "Mails.remove", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result MailsRemoveResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
+
+// MailsSetResult - result of Mails.set
+type MailsSetResult struct {
+	Errors ErrorList     `json:"errors"` // error message list
+	Result SetResultList `json:"result"`
+}
+
+// MailsSet - ErrorCodeSendingFailed - Failed to send email and failed to update mail.
+//
+//	mails - modifications of mails.
+//
+// Return
+//
+//	errors - error message list
+//	result
+func (c *ClientConnection) MailsSet(mails MailList) (ErrorList, SetResultList, error) {
+	return c.MailsSetContext(context.Background(), mails)
+}
+
+// MailsSetContext - the same as MailsSet, but the call is bound to ctx.
+func (c *ClientConnection) MailsSetContext(ctx context.Context, mails MailList) (ErrorList, SetResultList, error) {
+	params := struct {
+		Mails MailList `json:"mails"`
+	}{mails}
+	data, err := c.CallRawContext(ctx, "Mails.set", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result MailsSetResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// MailsSetAllSeen - Set all e-mail in folder as seen.
+//
+//	folderId - target folder
+func (c *ClientConnection) MailsSetAllSeen(folderId KId) error {
+	return c.MailsSetAllSeenContext(context.Background(), folderId)
+}
+
+// MailsSetAllSeenContext - the same as MailsSetAllSeen, but the call is bound to ctx.
+func (c *ClientConnection) MailsSetAllSeenContext(ctx context.Context, folderId KId) error {
+	params := struct {
+		FolderId KId `json:"folderId"`
+	}{folderId}
+	_, err := c.CallRawContext(ctx, "Mails.setAllSeen", params)
+	return err
+}
+
+// MailsCopyResult - result of Mails.copy
+type MailsCopyResult struct {
+	Errors ErrorList        `json:"errors"` // error message list
+	Result CreateResultList `json:"result"`
+}
+
+// MailsCopy - Copy existing e-mails to folder
+//
+//	ids - list of global identifiers of mails to be copied
+//	folder - target folder
+//
+// Return
+//
+//	errors - error message list
+//	result
+func (c *ClientConnection) MailsCopy(ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
+	return c.MailsCopyContext(context.Background(), ids, folder)
+}
+
+// MailsCopyContext - the same as MailsCopy, but the call is bound to ctx.
+func (c *ClientConnection) MailsCopyContext(ctx context.Context, ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
+	params := struct {
+		Ids    KIdList `json:"ids"`
+		Folder KId     `json:"folder"`
+	}{ids, folder}
+	data, err := c.CallRawContext(ctx, "Mails.copy", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result MailsCopyResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// MailsMoveResult - result of Mails.move
+type MailsMoveResult struct {
+	Errors ErrorList        `json:"errors"` // error message list
+	Result CreateResultList `json:"result"`
+}
+
+// MailsMove - Move existing e-mails to folder
+//
+//	ids - list of global identifiers of e-mails to be moved
+//	folder - target folder
+//
+// Return
+//
+//	errors - error message list
+//	result
+func (c *ClientConnection) MailsMove(ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
+	return c.MailsMoveContext(context.Background(), ids, folder)
+}
+
+// MailsMoveContext - the same as MailsMove, but the call is bound to ctx.
+func (c *ClientConnection) MailsMoveContext(ctx context.Context, ids KIdList, folder KId) (ErrorList, CreateResultList, error) {
+	params := struct {
+		Ids    KIdList `json:"ids"`
+		Folder KId     `json:"folder"`
+	}{ids, folder}
+	data, err := c.CallRawContext(ctx, "Mails.move", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result MailsMoveResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// MailsExportAttachmentsResult - result of Mails.exportAttachments
+type MailsExportAttachmentsResult struct {
+	FileDownload Download `json:"fileDownload"` // description of output file
+}
+
+// MailsExportAttachments - Export attachments from mail and pack them into zip.
+//
+//	attachmentIds - list of global identifiers of attachments. All attachments must be from the same e-mail.
+//
+// Return
+//
+//	fileDownload - description of output file
+func (c *ClientConnection) MailsExportAttachments(attachmentIds KIdList) (*Download, error) {
+	return c.MailsExportAttachmentsContext(context.Background(), attachmentIds)
+}
+
+// MailsExportAttachmentsContext - the same as MailsExportAttachments, but the call is bound to ctx.
+func (c *ClientConnection) MailsExportAttachmentsContext(ctx context.Context, attachmentIds KIdList) (*Download, error) {
+	params := struct {
+		AttachmentIds KIdList `json:"attachmentIds"`
+	}{attachmentIds}
+	data, err := c.CallRawContext(ctx, "Mails.exportAttachments", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result MailsExportAttachmentsResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.FileDownload, err
+}
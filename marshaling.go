@@ -12,3 +12,17 @@ func marshal(id int, method string, token *string, params interface{}) ([]byte,
 	}
 	return json.Marshal(&p)
 }
+
+func marshalBatch(calls []*BatchCall, token *string) ([]byte, error) {
+	p := make([]parameters, len(calls))
+	for i, call := range calls {
+		p[i] = parameters{
+			JsonRpc: "2.0",
+			Method:  call.Method,
+			ID:      call.id,
+			Token:   token,
+			Params:  call.params,
+		}
+	}
+	return json.Marshal(&p)
+}
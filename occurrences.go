@@ -1,7 +1,5 @@
 package webmail
 
-import "encoding/json"
-
 type ModificationType string
 
 const (
@@ -41,128 +39,3 @@ type Occurrence struct {
 }
 
 type OccurrenceList []Occurrence
-
-// Constants for composing kerio::web::SearchQuery
-
-// OccurrencesGet - Items rule and reminder in the occurrence aren't filled. If necessary use getOccurrence method.
-//	folderIds - list of global identifiers of folders to be listed
-//	query - query attributes and limits
-// Return
-//	list - all found events
-//  totalItems - number of events found if there is no limit
-func (c *ClientConnection) OccurrencesGet(folderIds KIdList, query SearchQuery) (OccurrenceList, int, error) {
-	query = addMissedParametersToSearchQuery(query)
-	params := struct {
-		FolderIds KIdList     `json:"folderIds"`
-		Query     SearchQuery `json:"query"`
-	}{folderIds, query}
-	data, err := c.CallRaw("Occurrences.get", params)
-	if err != nil {
-		return nil, 0, err
-	}
-	list := struct {
-		Result struct {
-			List       OccurrenceList `json:"list"`
-			TotalItems int            `json:"totalItems"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, list.Result.TotalItems, err
-}
-
-// OccurrencesGetById - Get an occurrence.
-// Return
-//	result - found occurrence
-func (c *ClientConnection) OccurrencesGetById(ids KIdList) (ErrorList, OccurrenceList, error) {
-	params := struct {
-		Ids KIdList `json:"ids"`
-	}{ids}
-	data, err := c.CallRaw("Occurrences.getById", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList      `json:"errors"`
-			Result OccurrenceList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// OccurrencesGetFromAttachment - Get an occurrence.
-//	attachmentId - global identifier of attachment
-// Return
-//	result - found occurrence
-func (c *ClientConnection) OccurrencesGetFromAttachment(attachmentId KId) (*Occurrence, error) {
-	params := struct {
-		AttachmentId KId `json:"attachmentId"`
-	}{attachmentId}
-	data, err := c.CallRaw("Occurrences.getFromAttachment", params)
-	if err != nil {
-		return nil, err
-	}
-	result := struct {
-		Result struct {
-			Result Occurrence `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &result)
-	return &result.Result.Result, err
-}
-
-// OccurrencesRemove - Remove a list of occurrences.
-//	occurrences - occurrences to be removed. Only fields 'id' and 'modification' are required.
-// Return
-//	errors - list of occurrences that failed to remove
-func (c *ClientConnection) OccurrencesRemove(occurrences OccurrenceList) (ErrorList, error) {
-	params := struct {
-		Occurrences OccurrenceList `json:"occurrences"`
-	}{occurrences}
-	data, err := c.CallRaw("Occurrences.remove", params)
-	if err != nil {
-		return nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList `json:"errors"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, err
-}
-
-// OccurrencesSet - Set occurrences.
-//	occurrences - modifications of occurrences.
-// Return
-//	errors - error message list
-func (c *ClientConnection) OccurrencesSet(occurrences OccurrenceList) (ErrorList, SetResultList, error) {
-	params := struct {
-		Occurrences OccurrenceList `json:"occurrences"`
-	}{occurrences}
-	data, err := c.CallRaw("Occurrences.set", params)
-	if err != nil {
-		return nil, nil, err
-	}
-	errors := struct {
-		Result struct {
-			Errors ErrorList     `json:"errors"`
-			Result SetResultList `json:"result"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &errors)
-	return errors.Result.Errors, errors.Result.Result, err
-}
-
-// OccurrencesSetPartStatus - Set part status to occurrence or event and send response to organizer.
-//	id - identifiers of events or occurrence
-//	response - response and status
-func (c *ClientConnection) OccurrencesSetPartStatus(id KId, response PartStatusResponse) error {
-	params := struct {
-		Id       KId                `json:"id"`
-		Response PartStatusResponse `json:"response"`
-	}{id, response}
-	_, err := c.CallRaw("Occurrences.setPartStatus", params)
-	return err
-}
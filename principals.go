@@ -1,7 +1,5 @@
 package webmail
 
-import "encoding/json"
-
 type PrincipalType string
 
 const (
@@ -21,46 +19,3 @@ type Principal struct {
 }
 
 type PrincipalList []Principal
-
-// PrincipalsGet - Get list of principals from server.
-// Return
-//	list - principals
-func (c *ClientConnection) PrincipalsGet(users bool, groups bool, domains bool) (PrincipalList, error) {
-	params := struct {
-		Users   bool `json:"users"`
-		Groups  bool `json:"groups"`
-		Domains bool `json:"domains"`
-	}{users, groups, domains}
-	data, err := c.CallRaw("Principals.get", params)
-	if err != nil {
-		return nil, err
-	}
-	list := struct {
-		Result struct {
-			List PrincipalList `json:"list"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &list)
-	return list.Result.List, err
-}
-
-// PrincipalsGetByEmail - Find principal according his primary email (login name) on server.
-//	email - email/login name
-// Return
-//	principal - principal
-func (c *ClientConnection) PrincipalsGetByEmail(email string) (*Principal, error) {
-	params := struct {
-		Email string `json:"email"`
-	}{email}
-	data, err := c.CallRaw("Principals.getByEmail", params)
-	if err != nil {
-		return nil, err
-	}
-	principal := struct {
-		Result struct {
-			Principal Principal `json:"principal"`
-		} `json:"result"`
-	}{}
-	err = json.Unmarshal(data, &principal)
-	return &principal.Result.Principal, err
-}
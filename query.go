@@ -0,0 +1,205 @@
+package webmail
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Unlimited - the limit of SearchQuery for unlimited count of items
+const Unlimited = -1
+
+// ErrInvalidQuery - the query built by QueryBuilder is not valid
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Field - name of the field used in conditions, fields and ordering of SearchQuery.
+// It is implemented by MailField, ContactField, EventField, OccurrenceField, TaskField and NoteField,
+// so only the fields of one kind of items can be used in a query.
+type Field interface {
+	fieldName() string
+	entity() string
+}
+
+// QueryBuilder - builder of SearchQuery, e.g.
+//	webmail.Query().Where(MailFieldSubject, Like, "%invoice%").And(MailFieldIsSeen, Eq, false).
+//		OrderBy(MailFieldReceiveDate, Desc).Limit(50).Build()
+// The first error is kept and returned by Build.
+type QueryBuilder struct {
+	query  SearchQuery
+	entity string
+	err    error
+}
+
+// Query returns a new builder of SearchQuery without conditions and limit
+func Query() *QueryBuilder {
+	return &QueryBuilder{
+		query: SearchQuery{
+			Fields:     StringList{},
+			Conditions: SubConditionList{},
+			Limit:      Unlimited,
+			OrderBy:    SortOrderList{},
+		},
+	}
+}
+
+// Where adds the condition, the following conditions are combined with And or Or.
+//	value - string, bool, number, time.Time or a type based on them (e.g. KId, UtcDateTime)
+func (b *QueryBuilder) Where(field Field, comparator CompareOperator, value interface{}) *QueryBuilder {
+	if !b.use(field) {
+		return b
+	}
+	switch comparator {
+	case Eq, NotEq, LessThan, GreaterThan, LessEq, GreaterEq, Like:
+	default:
+		b.fail("unknown comparator %q", comparator)
+		return b
+	}
+	text, err := formatQueryValue(value)
+	if err != nil {
+		b.fail("field %s: %v", field.fieldName(), err)
+		return b
+	}
+	b.query.Conditions = append(b.query.Conditions, SubCondition{
+		FieldName:  field.fieldName(),
+		Comparator: comparator,
+		Value:      text,
+	})
+	return b
+}
+
+// And adds the condition, all conditions must match.
+// SearchQuery cannot combine And and Or.
+func (b *QueryBuilder) And(field Field, comparator CompareOperator, value interface{}) *QueryBuilder {
+	return b.combine(And).Where(field, comparator, value)
+}
+
+// Or adds the condition, any of conditions must match.
+// SearchQuery cannot combine And and Or.
+func (b *QueryBuilder) Or(field Field, comparator CompareOperator, value interface{}) *QueryBuilder {
+	return b.combine(Or).Where(field, comparator, value)
+}
+
+// Fields limits the fields of returned items, all fields are returned by default
+func (b *QueryBuilder) Fields(fields ...Field) *QueryBuilder {
+	for _, field := range fields {
+		if b.use(field) {
+			b.query.Fields = append(b.query.Fields, field.fieldName())
+		}
+	}
+	return b
+}
+
+// OrderBy adds the sorting of items by the field, the first call has the highest priority
+func (b *QueryBuilder) OrderBy(field Field, direction SortDirection) *QueryBuilder {
+	return b.orderBy(field, direction, false)
+}
+
+// OrderByCaseSensitive is like OrderBy, but the strings are compared case-sensitively
+func (b *QueryBuilder) OrderByCaseSensitive(field Field, direction SortDirection) *QueryBuilder {
+	return b.orderBy(field, direction, true)
+}
+
+// Start sets how many items to skip before filling a result list
+func (b *QueryBuilder) Start(start int) *QueryBuilder {
+	if start < 0 {
+		b.fail("negative start %d", start)
+		return b
+	}
+	b.query.Start = start
+	return b
+}
+
+// Limit sets how many items to put to a result list, Unlimited by default
+func (b *QueryBuilder) Limit(limit int) *QueryBuilder {
+	if limit <= 0 && limit != Unlimited {
+		b.fail("limit %d, must be positive or Unlimited", limit)
+		return b
+	}
+	b.query.Limit = limit
+	return b
+}
+
+// Build returns the query or the first error wrapping ErrInvalidQuery
+func (b *QueryBuilder) Build() (SearchQuery, error) {
+	if b.err != nil {
+		return SearchQuery{}, b.err
+	}
+	query := b.query
+	if query.Combining == "" {
+		query.Combining = And
+	}
+	return query, nil
+}
+
+func (b *QueryBuilder) orderBy(field Field, direction SortDirection, caseSensitive bool) *QueryBuilder {
+	if !b.use(field) {
+		return b
+	}
+	if direction != Asc && direction != Desc {
+		b.fail("unknown direction %q", direction)
+		return b
+	}
+	b.query.OrderBy = append(b.query.OrderBy, SortOrder{
+		ColumnName:    field.fieldName(),
+		Direction:     direction,
+		CaseSensitive: caseSensitive,
+	})
+	return b
+}
+
+// combine sets the operator combining the conditions
+func (b *QueryBuilder) combine(operator LogicalOperator) *QueryBuilder {
+	if b.query.Combining != "" && b.query.Combining != operator {
+		b.fail("conditions cannot be combined by both %s and %s", b.query.Combining, operator)
+	}
+	b.query.Combining = operator
+	return b
+}
+
+// use checks that the field belongs to the same kind of items as the previous ones
+func (b *QueryBuilder) use(field Field) bool {
+	if b.err != nil {
+		return false
+	}
+	if field == nil {
+		b.fail("nil field")
+		return false
+	}
+	if b.entity == "" {
+		b.entity = field.entity()
+	}
+	if field.entity() != b.entity {
+		b.fail("field %s of %s in the query of %s", field.fieldName(), field.entity(), b.entity)
+		return false
+	}
+	return true
+}
+
+func (b *QueryBuilder) fail(format string, args ...interface{}) {
+	if b.err == nil {
+		b.err = fmt.Errorf("%w: %s", ErrInvalidQuery, fmt.Sprintf(format, args...))
+	}
+}
+
+// formatQueryValue returns the value of condition as string
+func formatQueryValue(value interface{}) (string, error) {
+	if t, ok := value.(time.Time); ok {
+		return string(UtcDateTimeFromTime(t)), nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("unsupported value %T", value)
+}
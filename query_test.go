@@ -0,0 +1,69 @@
+package webmail
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	since := time.Date(2021, 8, 5, 7, 15, 0, 0, time.UTC)
+	query, err := Query().
+		Where(MailFieldSubject, Like, "%invoice%").
+		And(MailFieldIsSeen, Eq, false).
+		And(MailFieldReceiveDate, GreaterEq, since).
+		And(MailFieldSize, LessThan, 1024).
+		Fields(MailFieldId, MailFieldSubject).
+		OrderBy(MailFieldReceiveDate, Desc).
+		Start(10).
+		Limit(50).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SearchQuery{
+		Fields: StringList{"id", "subject"},
+		Conditions: SubConditionList{
+			{FieldName: "subject", Comparator: Like, Value: "%invoice%"},
+			{FieldName: "isSeen", Comparator: Eq, Value: "false"},
+			{FieldName: "receiveDate", Comparator: GreaterEq, Value: "20210805T071500+0000"},
+			{FieldName: "size", Comparator: LessThan, Value: "1024"},
+		},
+		Combining: And,
+		Start:     10,
+		Limit:     50,
+		OrderBy:   SortOrderList{{ColumnName: "receiveDate", Direction: Desc}},
+	}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("got %+v, want %+v", query, want)
+	}
+}
+
+func TestQuery_Default(t *testing.T) {
+	query, err := Query().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(query, addMissedParametersToSearchQuery(query)) || query.Limit != Unlimited {
+		t.Errorf("got %+v", query)
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	tests := map[string]*QueryBuilder{
+		"mixed entities":  Query().Where(MailFieldSubject, Like, "x").Or(ContactFieldCommonName, Like, "x"),
+		"mixed combining": Query().Where(TaskFieldSummary, Like, "x").And(TaskFieldDone, Eq, 100).Or(TaskFieldStatus, Eq, "x"),
+		"comparator":      Query().Where(NoteFieldText, CompareOperator("Contains"), "x"),
+		"value":           Query().Where(EventFieldStart, Eq, []string{"x"}),
+		"direction":       Query().OrderBy(OccurrenceFieldStart, SortDirection("Up")),
+		"limit":           Query().Limit(0),
+		"start":           Query().Start(-1),
+		"order entity":    Query().Where(EventFieldSummary, Like, "x").OrderBy(MailFieldSubject, Asc),
+	}
+	for name, builder := range tests {
+		if _, err := builder.Build(); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s: got %v, want ErrInvalidQuery", name, err)
+		}
+	}
+}
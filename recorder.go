@@ -0,0 +1,242 @@
+package webmail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Interaction - recorded call with secrets masked by Redact
+type Interaction struct {
+	Method   string          `json:"method"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Cassette - calls recorded by Recorder and replayed by Replayer
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadCassette reads the cassette from file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err = json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Save writes the cassette to file
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Recorder - RoundTripper which records calls into a cassette file, use it with WithTransport.
+// The file is rewritten after each call.
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	transport http.RoundTripper
+	cassette  Cassette
+}
+
+// NewRecorder returns a Recorder which writes to path and sends requests with transport,
+// http.DefaultTransport is used if transport is nil
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{
+		path:      path,
+		transport: transport,
+	}
+}
+
+// RoundTrip sends the request and records its calls
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	calls, batch, err := splitCalls(body)
+	if err != nil {
+		return resp, nil
+	}
+	replies := map[int]json.RawMessage{}
+	if batch {
+		var list []json.RawMessage
+		_ = json.Unmarshal(data, &list)
+		for _, reply := range list {
+			replies[replyID(reply)] = reply
+		}
+	} else if len(calls) == 1 {
+		replies[calls[0].ID] = data
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, call := range calls {
+		if reply, ok := replies[call.ID]; ok {
+			r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+				Method:   call.Method,
+				Params:   normalizeParams(call.Params),
+				Response: Redact(reply),
+			})
+		}
+	}
+	if err = r.cassette.Save(r.path); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Replayer - RoundTripper which answers calls from a cassette without a server, use it with WithTransport.
+// Calls are matched by method and params, the request id is ignored.
+// Repeated calls get the recorded responses in order, the last one is repeated when they run out.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer of the cassette file
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// RoundTrip returns the recorded responses to the calls of the request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return nil, fmt.Errorf("no recorded response to %s %s", req.Method, req.URL)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	calls, batch, err := splitCalls(body)
+	if err != nil {
+		return nil, err
+	}
+	replies := make([]json.RawMessage, len(calls))
+	for i, call := range calls {
+		if replies[i], err = r.find(call); err != nil {
+			return nil, err
+		}
+	}
+	data := []byte(replies[0])
+	if batch {
+		if data, err = json.Marshal(replies); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+// find returns the recorded response to the call with id of the call
+func (r *Replayer) find(call recordedCall) (json.RawMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	params := normalizeParams(call.Params)
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Method != call.Method || !bytes.Equal(normalizeParams(interaction.Params), params) {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("no recorded response to %s %s", call.Method, params)
+	}
+	r.used[last] = true
+	reply := map[string]json.RawMessage{}
+	if err := json.Unmarshal(r.cassette.Interactions[last].Response, &reply); err != nil {
+		return nil, err
+	}
+	reply["id"] = json.RawMessage(fmt.Sprint(call.ID))
+	return json.Marshal(reply)
+}
+
+type recordedCall struct {
+	Method string          `json:"method"`
+	ID     int             `json:"id"`
+	Params json.RawMessage `json:"params"`
+}
+
+// splitCalls decodes the calls of a single or batch request
+func splitCalls(body []byte) ([]recordedCall, bool, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var calls []recordedCall
+		err := json.Unmarshal(body, &calls)
+		return calls, true, err
+	}
+	call := recordedCall{}
+	if err := json.Unmarshal(body, &call); err != nil {
+		return nil, false, err
+	}
+	return []recordedCall{call}, false, nil
+}
+
+func replyID(reply json.RawMessage) int {
+	head := struct {
+		ID int `json:"id"`
+	}{}
+	_ = json.Unmarshal(reply, &head)
+	return head.ID
+}
+
+// normalizeParams returns params with secrets masked and object members sorted
+func normalizeParams(params json.RawMessage) json.RawMessage {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	return Redact(params)
+}
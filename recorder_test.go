@@ -0,0 +1,29 @@
+package webmail
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_SaveError(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"jsonrpc":"2.0","id":1,"result":{}}`)),
+		}, nil
+	})
+	recorder := NewRecorder(filepath.Join(t.TempDir(), "missing", "cassette.json"), transport)
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"Session.getDomain"}`)
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/webmail/api/jsonrpc/", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := recorder.RoundTrip(req)
+	if err == nil || resp != nil {
+		t.Errorf("got %v, %v", resp, err)
+	}
+}
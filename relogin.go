@@ -0,0 +1,77 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// CredentialsFunc returns credentials used to log in again after the session expired
+type CredentialsFunc func(ctx context.Context) (*Credentials, error)
+
+// StaticCredentials returns CredentialsFunc which always returns the given user name and password
+func StaticCredentials(userName, password string) CredentialsFunc {
+	return func(context.Context) (*Credentials, error) {
+		return &Credentials{UserName: userName, Password: password}, nil
+	}
+}
+
+type autoRelogin struct {
+	mu          sync.Mutex // serializes logins of concurrent calls
+	credentials CredentialsFunc
+	app         ApiApplication
+}
+
+// SetAutoRelogin enables automatic re-login. When a call fails because the session expired
+// (ErrorCodeSessionExpired or ErrorCodeOperatorSessionExpired), the connection logs in again
+// with the credentials and app, refreshes the session token and replays the failed call once.
+// Concurrent calls failing on the same expired session share one login.
+// A nil credentials disables re-login.
+//	credentials - source of user name and password
+//	app - application descriminator, a default one is used if nil
+func (c *ClientConnection) SetAutoRelogin(credentials CredentialsFunc, app *ApiApplication) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if credentials == nil {
+		c.relogin = nil
+		return
+	}
+	if app == nil {
+		app = NewApplication("", "", "")
+	}
+	c.relogin = &autoRelogin{
+		credentials: credentials,
+		app:         *app,
+	}
+}
+
+func (c *ClientConnection) autoRelogin() *autoRelogin {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.relogin
+}
+
+func (c *ClientConnection) needRelogin(method string, err error) bool {
+	if method == "Session.login" || method == "Session.logout" || c.autoRelogin() == nil {
+		return false
+	}
+	return errors.Is(err, ErrSessionExpired) || errors.Is(err, ErrOperatorSessionExpired)
+}
+
+// reloginContext logs in again unless another call has already replaced the expired token
+func (c *ClientConnection) reloginContext(ctx context.Context, expired *string) error {
+	r := c.autoRelogin()
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c.token() != expired {
+		return nil
+	}
+	credentials, err := r.credentials(ctx)
+	if err != nil {
+		return err
+	}
+	return c.LoginContext(ctx, credentials.UserName, credentials.Password, &r.app)
+}
@@ -0,0 +1,65 @@
+package webmail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientConnection_SetAutoRelogin(t *testing.T) {
+	var logins int32
+	var mu sync.Mutex
+	current := "expired"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := parameters{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method == "Session.login" {
+			n := atomic.AddInt32(&logins, 1)
+			current = fmt.Sprintf("token%d", n)
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"token":%q}}`, req.ID, current)
+			return
+		}
+		if r.Header.Get("X-Token") != current {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32001,"message":"Session expired."}}`, req.ID)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"isEligible":true}}`, req.ID)
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired := "stale"
+	conn.SetToken(expired)
+	conn.SetAutoRelogin(StaticCredentials("user", "password"), nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := conn.SessionCanUserChangePassword()
+			if err != nil || !ok {
+				t.Errorf("unexpected result %v, %v", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if logins != 1 {
+		t.Errorf("expected 1 login, got %d", logins)
+	}
+	conn.SetAutoRelogin(nil, nil)
+	mu.Lock()
+	current = "other"
+	mu.Unlock()
+	if _, err = conn.SessionCanUserChangePassword(); err == nil {
+		t.Error("expected session expired error")
+	}
+}
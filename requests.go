@@ -1,64 +1,185 @@
+//go:generate go run ./cmd/webmailgen
+
 package webmail
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/cookiejar"
+	"sync"
+	"time"
 )
 
+// ClientConnection - connection to the API server with its session.
+// It is safe for concurrent use by multiple goroutines. Batch is not safe for concurrent use.
 type ClientConnection struct {
-	Config *Config
-	Token  *string
-	client *http.Client
+	Config       *Config
+	sessionToken *string // set by Login and the auto re-login
+	client       *http.Client
+	mu           sync.RWMutex // guards sessionToken, app, relogin and interceptors
+	app          *ApiApplication
+	relogin      *autoRelogin
+	interceptors []Interceptor
 }
 
-func (c *Config) NewConnection() (*ClientConnection, error) {
-	client, err := newClient()
+// NewConnection returns a new connection to the API server.
+//  options - settings overriding the ones of the config for this connection only
+func (c *Config) NewConnection(options ...Option) (*ClientConnection, error) {
+	config := c
+	if len(options) > 0 {
+		config = c.clone()
+		config.apply(options)
+	}
+	client, err := config.newClient()
 	if err != nil {
 		return nil, err
 	}
 	connection := ClientConnection{
-		Config: c,
+		Config: config,
 		client: client,
 	}
 	return &connection, nil
 }
 
-func newClient() (*http.Client, error) {
+func (c *Config) newClient() (*http.Client, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, err
 	}
-	return &http.Client{Jar: jar}, nil
+	if c.client != nil {
+		client := *c.client
+		if client.Jar == nil {
+			client.Jar = jar
+		}
+		return &client, nil
+	}
+	transport := c.transport
+	if c.tlsConfig != nil {
+		base, ok := transport.(*http.Transport)
+		if transport == nil {
+			base, ok = http.DefaultTransport.(*http.Transport)
+		}
+		if !ok {
+			return nil, fmt.Errorf("TLS config can not be applied to transport %T, set it in the transport", transport)
+		}
+		clone := base.Clone()
+		clone.TLSClientConfig = c.tlsConfig
+		transport = clone
+	}
+	return &http.Client{Jar: jar, Transport: transport}, nil
 }
 
+// CallRaw sends a JSON-RPC request with the given method and params and returns the raw response
 func (c *ClientConnection) CallRaw(method string, params interface{}) ([]byte, error) {
-	buffer, err := marshal(c.Config.getID(), method, c.Token, params)
+	return c.CallRawContext(context.Background(), method, params)
+}
+
+// CallRawContext is like CallRaw, but the HTTP request is bound to ctx,
+// so the call is aborted as soon as ctx is canceled or its deadline expires
+func (c *ClientConnection) CallRawContext(ctx context.Context, method string, params interface{}) ([]byte, error) {
+	return c.chain(c.invoke)(ctx, method, params)
+}
+
+// invoke performs the call with retries and re-login, it is the last item of the interceptor chain
+func (c *ClientConnection) invoke(ctx context.Context, method string, params interface{}) ([]byte, error) {
+	token := c.token()
+	data, err := c.doWithRetry(ctx, method, token, params)
+	if err != nil && c.needRelogin(method, err) {
+		if err = c.reloginContext(ctx, token); err != nil {
+			return nil, err
+		}
+		data, err = c.doWithRetry(ctx, method, c.token(), params)
+	}
+	return data, err
+}
+
+// do performs a single JSON-RPC round-trip
+func (c *ClientConnection) do(ctx context.Context, method string, token *string, params interface{}) ([]byte, error) {
+	id := c.Config.getID()
+	buffer, err := marshal(id, method, token, params)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.Config.url, bytes.NewBuffer(buffer))
+	start := time.Now()
+	data, err := c.post(ctx, token, buffer)
+	if err == nil {
+		err = checkError(data)
+	}
+	c.logCall(ctx, CallRecord{
+		Method:       method,
+		ID:           id,
+		Duration:     time.Since(start),
+		ResponseSize: len(data),
+		Err:          err,
+	}, token, buffer)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "ApiApplication/json-rpc")
-	if c.Token != nil {
-		req.Header.Add("X-Token", *c.Token)
+	return data, nil
+}
+
+// post sends the encoded JSON-RPC request body and returns the response body
+func (c *ClientConnection) post(ctx context.Context, token *string, buffer []byte) ([]byte, error) {
+	if c.Config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.timeout)
+		defer cancel()
 	}
-	resp, err := c.client.Do(req)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Config.url, bytes.NewBuffer(buffer))
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
-	data, err := ioutil.ReadAll(resp.Body)
+	req.Header = newRequestHeader(ctx, token)
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	if err = checkError(data); err != nil {
-		return nil, err
+	defer func() { _ = resp.Body.Close() }()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// newRequestHeader returns headers of the request with the session token and headers added to ctx
+func newRequestHeader(ctx context.Context, token *string) http.Header {
+	header := http.Header{}
+	header.Set("Content-Type", "ApiApplication/json-rpc")
+	for key, values := range requestHeader(ctx) {
+		for _, value := range values {
+			header.Add(key, value)
+		}
 	}
-	return data, nil
+	if token != nil {
+		header.Add("X-Token", *token)
+	}
+	return header
+}
+
+// Token returns the session token, empty string if the connection is not logged in
+func (c *ClientConnection) Token() string {
+	if token := c.token(); token != nil {
+		return *token
+	}
+	return ""
+}
+
+// SetToken sets the session token, e.g. the one of the session logged in by another connection.
+// Empty token means the connection is not logged in.
+func (c *ClientConnection) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token == "" {
+		c.sessionToken = nil
+		return
+	}
+	c.sessionToken = &token
+}
+
+func (c *ClientConnection) token() *string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sessionToken
 }
 
 func addMissedParametersToSearchQuery(query SearchQuery) SearchQuery {
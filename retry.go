@@ -0,0 +1,123 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultRetryCodes - error codes which mean that the server is temporarily unable to perform the call
+var DefaultRetryCodes = []int{
+	ErrorCodeOperationInProgress,
+	ErrorCodeFolderReindexing,
+	ErrorCodeMultiServerBackendMaintenance,
+	ErrorCodeTimedout,
+}
+
+// RetryPolicy - rules for repeating of failed calls
+type RetryPolicy struct {
+	MaxAttempts        int           // number of attempts including the first one; 0 or 1 means no retries
+	MinBackoff         time.Duration // delay before the first retry, it is doubled for each next retry
+	MaxBackoff         time.Duration // upper limit of the delay, 0 means no limit
+	Jitter             float64       // random part of the delay, 0 to 1; e.g. 0.2 means the delay is reduced by up to 20%
+	RetryCodes         []int         // error codes to retry, DefaultRetryCodes if nil
+	RetryNetworkErrors bool          // retry network failures, note that the call may have been already performed by server
+	RetryNonIdempotent bool          // retry also methods changing data (create, set, move, ...), e.g. Mails.create with Send
+	// OnRetry is called before waiting for each retry
+	//	attempt - number of the failed attempt, starting with 1
+	OnRetry func(method string, attempt int, delay time.Duration, err error)
+}
+
+// DefaultRetryPolicy returns the policy with 3 attempts and exponential backoff from 500ms up to 5s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:        3,
+		MinBackoff:         500 * time.Millisecond,
+		MaxBackoff:         5 * time.Second,
+		Jitter:             0.2,
+		RetryNetworkErrors: true,
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the calls, nil means no retries.
+// Batches are never retried.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Config) {
+		c.retry = policy
+	}
+}
+
+// idempotentPrefixes - prefixes of the method names which only read data
+var idempotentPrefixes = []string{"get", "can", "check", "whoAmI"}
+
+func isIdempotent(method string) bool {
+	name := method[strings.LastIndex(method, ".")+1:]
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryable(method string, err error) bool {
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		codes := p.RetryCodes
+		if codes == nil {
+			codes = DefaultRetryCodes
+		}
+		for _, code := range codes {
+			if apiErr.Code == code {
+				return true
+			}
+		}
+		return false
+	}
+	var netErr net.Error
+	return p.RetryNetworkErrors && (errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+// backoff returns the delay before the retry following the attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// doWithRetry performs the call and repeats it according to the retry policy of the config
+func (c *ClientConnection) doWithRetry(ctx context.Context, method string, token *string, params interface{}) ([]byte, error) {
+	p := c.Config.retry
+	for attempt := 1; ; attempt++ {
+		data, err := c.do(ctx, method, token, params)
+		if err == nil || p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !p.retryable(method, err) {
+			return data, err
+		}
+		delay := p.backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(method, attempt, delay, err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package webmail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := parameters{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		calls[req.Method]++
+		if calls[req.Method] < 3 {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":4101,"message":"Try later"}}`, req.ID)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"errors":[],"result":[]}}`, req.ID)
+	}))
+	defer server.Close()
+	var retries []int
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+		Jitter:      0.5,
+		OnRetry: func(method string, attempt int, delay time.Duration, err error) {
+			if delay > 2*time.Millisecond || !errors.Is(err, ErrOperationInProgress) {
+				t.Errorf("unexpected retry of %s: %v, %v", method, delay, err)
+			}
+			retries = append(retries, attempt)
+		},
+	}
+	conf := &Config{url: server.URL, retry: policy}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = conn.MailsGetById(KIdList{"1"}); err != nil {
+		t.Error(err)
+	}
+	if len(retries) != 2 || retries[0] != 1 || retries[1] != 2 {
+		t.Errorf("unexpected retries %v", retries)
+	}
+	if _, _, err = conn.MailsCreate(MailList{{Send: true}}); !errors.Is(err, ErrOperationInProgress) {
+		t.Errorf("non-idempotent method retried: %v", err)
+	}
+	if calls["Mails.create"] != 1 {
+		t.Errorf("expected 1 call of Mails.create, got %d", calls["Mails.create"])
+	}
+}
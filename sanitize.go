@@ -0,0 +1,464 @@
+package webmail
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HTMLSanitizer - removes the unsafe content of HTML mail parts, so they can be embedded into a page.
+// Only the allowed elements and attributes are kept, scripts, event handlers, forms and frames are removed.
+// The style elements and class attributes are removed, so the mail can not restyle the page or reuse its classes,
+// and the inline styles can not position the content over the page.
+type HTMLSanitizer struct {
+	Attachments  AttachmentList                     // inline attachments referenced by cid: URLs
+	Proxy        func(attachment Attachment) string // returns the URL of the attachment served by an authenticated proxy, Attachment.Url is used if nil
+	ShowExternal bool                               // keep the remote images, which are removed otherwise because they can track the reader
+}
+
+// SanitizeMailHTML returns the sanitized HTML part of the mail, or its plain text part as HTML if there is no HTML part.
+// The remote images are removed unless Mail.ShowExternal is set.
+//	mail - the mail with displayable parts and attachments
+//	proxy - returns the URL of the inline attachment served by an authenticated proxy, Attachment.Url is used if nil
+// Return
+//	content - HTML safe to be embedded into a page
+//	blocked - true if some remote images were removed
+func SanitizeMailHTML(mail Mail, proxy func(attachment Attachment) string) (string, bool) {
+	if content, ok := partContent(mail, CtTextHtml); ok {
+		sanitizer := &HTMLSanitizer{Attachments: mail.Attachments, Proxy: proxy, ShowExternal: mail.ShowExternal}
+		return sanitizer.Sanitize(content)
+	}
+	text, _ := partContent(mail, CtTextPlain)
+	return textToHTML(text), false
+}
+
+// Sanitize returns the content with the allowed elements and attributes only, the links open a new window.
+// The cid: URLs are rewritten to the URLs of Attachments, the remote images are removed unless ShowExternal is set.
+// Return
+//	content - HTML safe to be embedded into a page
+//	blocked - true if some remote images were removed
+func (s *HTMLSanitizer) Sanitize(content string) (string, bool) {
+	var b strings.Builder
+	var open []string
+	blocked := false
+	skip, skipDepth := "", 0
+	for _, token := range tokenizeHTML(content) {
+		if skip != "" {
+			if token.data == skip && token.typ == htmlStartTag && !token.selfClosing {
+				skipDepth++
+			} else if token.data == skip && token.typ == htmlEndTag {
+				skipDepth--
+			}
+			if skipDepth == 0 {
+				skip = ""
+			}
+			continue
+		}
+		switch token.typ {
+		case htmlText:
+			b.WriteString(html.EscapeString(token.data))
+		case htmlStartTag:
+			if droppedElements[token.data] {
+				if !token.selfClosing {
+					skip, skipDepth = token.data, 1
+				}
+				continue
+			}
+			if !allowedElements[token.data] {
+				continue
+			}
+			attributes, ok := s.attributes(token, &blocked)
+			if !ok {
+				continue
+			}
+			b.WriteString("<" + token.data)
+			for _, attribute := range attributes {
+				b.WriteString(" " + attribute.name + `="` + html.EscapeString(attribute.value) + `"`)
+			}
+			b.WriteString(">")
+			if !voidElements[token.data] {
+				open = append(open, token.data)
+			}
+		case htmlEndTag:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.data {
+					for len(open) > i {
+						b.WriteString("</" + open[len(open)-1] + ">")
+						open = open[:len(open)-1]
+					}
+					break
+				}
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String(), blocked
+}
+
+// attributes returns the allowed attributes of the element with safe URLs and styles,
+// false if the element must be removed, i.e. the image without source
+func (s *HTMLSanitizer) attributes(token htmlToken, blocked *bool) ([]htmlAttribute, bool) {
+	var result []htmlAttribute
+	link := false
+	for _, attribute := range token.attrs {
+		value, ok := attribute.value, true
+		switch attribute.name {
+		case "href":
+			value, ok = safeLink(value)
+			link = link || ok
+		case "src", "background":
+			value, ok = s.imageURL(value, blocked)
+		case "style":
+			value = s.sanitizeCSS(value, blocked)
+			ok = value != ""
+		default:
+			ok = allowedAttributes[attribute.name]
+		}
+		if ok {
+			result = append(result, htmlAttribute{name: attribute.name, value: value})
+		}
+	}
+	if token.data == "img" {
+		hasSource := false
+		for _, attribute := range result {
+			hasSource = hasSource || attribute.name == "src"
+		}
+		if !hasSource {
+			return nil, false
+		}
+	}
+	if link {
+		result = append(result, htmlAttribute{"target", "_blank"}, htmlAttribute{"rel", "noopener noreferrer"})
+	}
+	return result, true
+}
+
+// imageURL returns the URL of the attachment for cid: URL, the remote URL if ShowExternal is set or the data URL of an image
+func (s *HTMLSanitizer) imageURL(value string, blocked *bool) (string, bool) {
+	switch scheme, rest := splitURLScheme(value); scheme {
+	case "cid":
+		contentId := strings.Trim(rest, "<> ")
+		for _, attachment := range s.Attachments {
+			if contentId == "" || !strings.EqualFold(attachment.ContentId, contentId) {
+				continue
+			}
+			u := attachment.Url
+			if s.Proxy != nil {
+				u = s.Proxy(attachment)
+			}
+			return u, u != ""
+		}
+	case "http", "https":
+		if s.ShowExternal {
+			return strings.TrimSpace(value), true
+		}
+		*blocked = true
+	case "data":
+		rest = strings.ToLower(rest)
+		for _, prefix := range []string{"image/png", "image/gif", "image/jpeg", "image/jpg", "image/webp", "image/bmp"} {
+			if strings.HasPrefix(rest, prefix+";") || strings.HasPrefix(rest, prefix+",") {
+				return strings.TrimSpace(value), true
+			}
+		}
+	}
+	return "", false
+}
+
+var (
+	cssComment = regexp.MustCompile(`(?s)/\*.*?\*/|<!--|-->`)
+	cssURL     = regexp.MustCompile(`(?i)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)\s]*))\s*\)`)
+	cssUnsafe  = regexp.MustCompile(`(?i)expression|javascript:|vbscript:|behavior|-moz-binding|@import|\\|<`)
+	// cssPosition - position declarations, which could place the content over the page
+	cssPosition = regexp.MustCompile(`(?i)^\s*position\s*:`)
+	// cssImageFunction - functions loading the images of string URLs, which are not rewritten like url()
+	cssImageFunction = regexp.MustCompile(`(?i)(?:^|[^\w-])(?:-webkit-)?(?:image-set|cross-fade|image|src)\s*\(`)
+	cssRemoteURL     = regexp.MustCompile(`(?i)https?:|//`)
+)
+
+// sanitizeCSS returns the declarations of style attribute without position and image-set, cross-fade or other image
+// functions, and with the URLs of images rewritten like the ones of img element, empty string if it can run a script
+// or load other content
+func (s *HTMLSanitizer) sanitizeCSS(css string, blocked *bool) string {
+	css = cssComment.ReplaceAllString(css, "")
+	declarations := splitCSSDeclarations(css)
+	kept := declarations[:0]
+	for _, declaration := range declarations {
+		switch {
+		case cssPosition.MatchString(declaration):
+		case cssImageFunction.MatchString(declaration):
+			if !s.ShowExternal && cssRemoteURL.MatchString(declaration) {
+				*blocked = true
+			}
+		default:
+			kept = append(kept, declaration)
+		}
+	}
+	css = strings.Join(kept, ";")
+	css = cssURL.ReplaceAllStringFunc(css, func(match string) string {
+		parts := cssURL.FindStringSubmatch(match)
+		u, ok := s.imageURL(parts[1]+parts[2]+parts[3], blocked)
+		if !ok || strings.ContainsAny(u, "\"\\\n\r") {
+			return "none"
+		}
+		return `url("` + u + `")`
+	})
+	if cssUnsafe.MatchString(css) {
+		return ""
+	}
+	return strings.Trim(css, "; \t\r\n")
+}
+
+// splitCSSDeclarations returns the declarations separated by semicolons out of the strings and parentheses
+func splitCSSDeclarations(css string) []string {
+	var declarations []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(css); i++ {
+		switch c := css[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ';' && depth == 0:
+			declarations = append(declarations, css[start:i])
+			start = i + 1
+		}
+	}
+	return append(declarations, css[start:])
+}
+
+// safeLink returns the link with scheme http, https, mailto, tel or ftp, or the link to the fragment of the document
+func safeLink(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	scheme, _ := splitURLScheme(value)
+	switch scheme {
+	case "http", "https", "mailto", "tel", "ftp":
+		return value, true
+	case "":
+		return value, strings.HasPrefix(value, "#")
+	}
+	return "", false
+}
+
+// splitURLScheme returns the scheme of URL in lower case and the rest after colon.
+// The control characters and spaces are ignored like browsers do.
+func splitURLScheme(value string) (string, string) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, value)
+	i := strings.IndexByte(cleaned, ':')
+	if i <= 0 || strings.ContainsAny(cleaned[:i], "/?#") {
+		return "", cleaned
+	}
+	return strings.ToLower(cleaned[:i]), cleaned[i+1:]
+}
+
+// allowedElements - elements kept by HTMLSanitizer, the others are removed with their content kept
+var allowedElements = setOf("a", "abbr", "address", "area", "article", "aside", "b", "bdi", "bdo", "big", "blockquote",
+	"br", "caption", "center", "cite", "code", "col", "colgroup", "dd", "del", "details", "dfn", "div", "dl", "dt", "em",
+	"figcaption", "figure", "font", "footer", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "i", "img", "ins", "kbd",
+	"li", "map", "mark", "ol", "p", "pre", "q", "s", "samp", "section", "small", "span", "strike", "strong", "sub",
+	"summary", "sup", "table", "tbody", "td", "tfoot", "th", "thead", "time", "tr", "tt", "u", "ul", "var", "wbr")
+
+// droppedElements - elements removed with their content
+var droppedElements = setOf("script", "iframe", "frame", "frameset", "object", "embed", "applet", "noscript", "noembed",
+	"noframes", "style", "title", "textarea", "select", "svg", "math", "template", "xmp", "plaintext")
+
+// voidElements - elements without end tag
+var voidElements = setOf("area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source",
+	"track", "wbr")
+
+// allowedAttributes - attributes kept by HTMLSanitizer besides href, src, background and style, which are checked
+var allowedAttributes = setOf("abbr", "align", "alt", "bgcolor", "border", "cellpadding", "cellspacing", "cite",
+	"color", "cols", "colspan", "coords", "datetime", "dir", "face", "headers", "height", "hspace", "lang", "nowrap",
+	"reversed", "rows", "rowspan", "scope", "shape", "size", "span", "start", "summary", "title", "type", "valign", "vspace",
+	"width")
+
+func setOf(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+type htmlTokenType int
+
+const (
+	htmlText htmlTokenType = iota
+	htmlStartTag
+	htmlEndTag
+)
+
+type htmlAttribute struct {
+	name  string // in lower case
+	value string // with the character references decoded
+}
+
+type htmlToken struct {
+	typ         htmlTokenType
+	data        string // name of element in lower case, or text with the character references decoded
+	attrs       []htmlAttribute
+	selfClosing bool
+}
+
+// rawTextElements - elements whose content is text, it is decoded except script and style
+var rawTextElements = setOf("script", "style", "textarea", "title", "xmp", "iframe", "noembed", "noframes", "noscript")
+
+// tokenizeHTML splits the HTML into text, start tags and end tags; comments, doctype and processing instructions are skipped
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	text := func(t string, decode bool) {
+		if t == "" {
+			return
+		}
+		if decode {
+			t = html.UnescapeString(t)
+		}
+		tokens = append(tokens, htmlToken{typ: htmlText, data: t})
+	}
+	for i := 0; i < len(s); {
+		j := strings.IndexByte(s[i:], '<')
+		if j < 0 {
+			text(s[i:], true)
+			break
+		}
+		text(s[i:i+j], true)
+		i += j
+		rest := s[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return tokens
+			}
+			i += 4 + end + 3
+		case len(rest) > 1 && (rest[1] == '!' || rest[1] == '?'):
+			i += skipTag(rest)
+		case len(rest) > 1 && rest[1] == '/':
+			if len(rest) > 2 && isASCIILetter(rest[2]) {
+				name, _ := tagName(rest[2:])
+				tokens = append(tokens, htmlToken{typ: htmlEndTag, data: name})
+			}
+			i += skipTag(rest)
+		case len(rest) > 1 && isASCIILetter(rest[1]):
+			token, n := parseStartTag(rest)
+			tokens = append(tokens, token)
+			i += n
+			if rawTextElements[token.data] && !token.selfClosing {
+				end := indexEndTag(s[i:], token.data)
+				text(s[i:i+end], token.data != "script" && token.data != "style")
+				i += end
+			}
+		default:
+			text("<", false)
+			i++
+		}
+	}
+	return tokens
+}
+
+// parseStartTag returns the start tag at the beginning of s and its length
+func parseStartTag(s string) (htmlToken, int) {
+	name, n := tagName(s[1:])
+	token := htmlToken{typ: htmlStartTag, data: name}
+	seen := map[string]bool{}
+	i := 1 + n
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '>':
+			return token, i + 1
+		case c == '/' && i+1 < len(s) && s[i+1] == '>':
+			token.selfClosing = true
+			return token, i + 2
+		case c == '/' || isHTMLSpace(c):
+			i++
+		default:
+			start := i
+			for i++; i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '='; i++ {
+			}
+			name := strings.ToLower(s[start:i])
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			value := ""
+			if i < len(s) && s[i] == '=' {
+				for i++; i < len(s) && isHTMLSpace(s[i]); i++ {
+				}
+				if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+					end := strings.IndexByte(s[i+1:], s[i])
+					if end < 0 {
+						return token, len(s)
+					}
+					value = s[i+1 : i+1+end]
+					i += end + 2
+				} else {
+					start := i
+					for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+						i++
+					}
+					value = s[start:i]
+				}
+			}
+			// the first of the duplicate attributes is used
+			if !seen[name] {
+				seen[name] = true
+				token.attrs = append(token.attrs, htmlAttribute{name: name, value: html.UnescapeString(value)})
+			}
+		}
+	}
+	return token, len(s)
+}
+
+// tagName returns the name of element at the beginning of s in lower case and its length in s
+func tagName(s string) (string, int) {
+	i := 0
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	return strings.ToLower(s[:i]), i
+}
+
+// skipTag returns the length of the tag at the beginning of s
+func skipTag(s string) int {
+	if end := strings.IndexByte(s, '>'); end >= 0 {
+		return end + 1
+	}
+	return len(s)
+}
+
+// indexEndTag returns the index of the end tag of the element in s, or the length of s
+func indexEndTag(s, name string) int {
+	tag := "</" + name
+	for i := strings.IndexByte(s, '<'); i >= 0 && i+len(tag) <= len(s); {
+		if strings.EqualFold(s[i:i+len(tag)], tag) && (i+len(tag) == len(s) || isHTMLSpace(s[i+len(tag)]) ||
+			s[i+len(tag)] == '>' || s[i+len(tag)] == '/') {
+			return i
+		}
+		next := strings.IndexByte(s[i+1:], '<')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return len(s)
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
@@ -0,0 +1,70 @@
+package webmail
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHTMLSanitizer_Sanitize(t *testing.T) {
+	sanitizer := &HTMLSanitizer{
+		Attachments: AttachmentList{{ContentId: "logo@example.com", Url: "/webmail/api/download/attachment/1/logo.png"}},
+		Proxy: func(attachment Attachment) string {
+			return "/proxy?url=" + url.QueryEscape(attachment.Url)
+		},
+	}
+	tests := []struct {
+		html    string
+		want    string
+		blocked bool
+	}{
+		{`<p onclick="alert(1)" class="x">Hi <b>there</b></p>`, `<p>Hi <b>there</b></p>`, false},
+		{`<script>alert("<p>")</script><div>a</div>`, `<div>a</div>`, false},
+		{`<SCRIPT src=x></SCRIPT ><iframe src="https://evil"></iframe>b`, `b`, false},
+		{`<a href=" java&#09;script:alert(1)">x</a>`, `<a>x</a>`, false},
+		{`<a href="https://example.com/?a=1&amp;b=2" target="_self">x</a>`,
+			`<a href="https://example.com/?a=1&amp;b=2" target="_blank" rel="noopener noreferrer">x</a>`, false},
+		{`<img src="cid:logo@example.com" alt="Logo">`,
+			`<img src="/proxy?url=%2Fwebmail%2Fapi%2Fdownload%2Fattachment%2F1%2Flogo.png" alt="Logo">`, false},
+		{`<img src="http://tracker.example.com/pixel.gif">text`, `text`, true},
+		{`<img src="data:image/svg+xml;base64,PHN2Zz4=">`, ``, false},
+		{`<td background="https://example.com/bg.png" style="color: red; background: url('https://example.com/bg.png')">x`,
+			`<td style="color: red; background: none">x</td>`, true},
+		{`<div style="color: red; background-image: image-set(&#34;https://example.com/a.png&#34; 1x)">x</div>`,
+			`<div style="color: red">x</div>`, true},
+		{`<div style="background: -WEBKIT-image-set('//example.com/a.png' 1x); top: 0">x</div>`,
+			`<div style="top: 0">x</div>`, true},
+		{`<div style="background-image: cross-fade('data:image/png;base64,AA==', 'a.png' 50%); margin: 0">x</div>`,
+			`<div style="margin: 0">x</div>`, false},
+		{`<div style="width: expression(alert(1))">x</div>`, `<div>x</div>`, false},
+		{`<style>body { display: none }</style><p>x</p>`, `<p>x</p>`, false},
+		{`<div style="position:absolute; z-index: -1; top: 0">x</div>`, `<div style="z-index: -1; top: 0">x</div>`, false},
+		{`<div style="color: red;POSITION : fixed;background-position: center;position:absolute">x</div>`,
+			`<div style="color: red;background-position: center">x</div>`, false},
+		{`<p>a <!-- <script>alert(1)</script> --> b</p>`, `<p>a  b</p>`, false},
+		{`<form action="https://evil"><input name="password">x</form>`, `x`, false},
+		{`<svg><script>alert(1)</script></svg>y`, `y`, false},
+		{`<p><b>unclosed</p>`, `<p><b>unclosed</b></p>`, false},
+		{`<p title='a"b' >1 < 2 &amp; 3</p >`, `<p title="a&#34;b">1 &lt; 2 &amp; 3</p>`, false},
+		{`<textarea></textarea><img src=x onerror=alert(1)>`, ``, false},
+	}
+	for _, test := range tests {
+		got, blocked := sanitizer.Sanitize(test.html)
+		if got != test.want || blocked != test.blocked {
+			t.Errorf("%s:\ngot  %s, %v\nwant %s, %v", test.html, got, blocked, test.want, test.blocked)
+		}
+	}
+}
+
+func TestSanitizeMailHTML(t *testing.T) {
+	mail := Mail{
+		ShowExternal:     true,
+		DisplayableParts: DisplayableMimePartList{{ContentType: CtTextHtml, Content: `<img src="https://example.com/a.png">`}},
+	}
+	if got, blocked := SanitizeMailHTML(mail, nil); got != `<img src="https://example.com/a.png">` || blocked {
+		t.Errorf("got %s, %v", got, blocked)
+	}
+	mail.DisplayableParts = DisplayableMimePartList{{ContentType: CtTextPlain, Content: "a < b\nc"}}
+	if got, _ := SanitizeMailHTML(mail, nil); got != "a &lt; b<br>c" {
+		t.Errorf("got %s", got)
+	}
+}
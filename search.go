@@ -0,0 +1,398 @@
+package webmail
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidSearch - the search expression cannot be parsed or the query has no text form
+var ErrInvalidSearch = errors.New("invalid search expression")
+
+// SearchGrammar - operators of the search expression for one kind of items, see MailSearch
+type SearchGrammar struct {
+	name      string
+	text      Field // field of words without operator
+	operators []searchOperator
+}
+
+// searchOperator - operator of the search expression, e.g. "from:alice" or "is:unread"
+type searchOperator struct {
+	name   string                                                    // e.g. "from"
+	word   string                                                    // the only value of the operator, e.g. "unread" for "is:unread"
+	parse  func(value string) (Field, CompareOperator, string, error) // converts the value to the condition
+	format func(condition SubCondition) (string, bool)               // converts the condition to the value
+}
+
+// searchKeywords - operators combining the terms of the search expression
+var searchKeywords = map[string]LogicalOperator{"AND": And, "OR": Or}
+
+// MailSearch - search expression of e-mails, e.g.
+//	from:alice subject:"Q3 report" after:2026-01-01 has:attachment is:unread invoice
+// Operators: from, to, cc, bcc, subject, after, before (date as 2006-01-02 or RFC 3339),
+// larger, smaller (size in bytes with optional K, M or G), has:attachment,
+// is:read, is:unread, is:flagged, is:unflagged, is:answered, is:forwarded, is:draft, is:junk.
+// Words without operator are searched in the main text fields.
+var MailSearch = &SearchGrammar{
+	name: "e-mail",
+	text: MailFieldQuickSearch,
+	operators: []searchOperator{
+		textOperator("from", MailFieldFrom),
+		textOperator("to", MailFieldTo),
+		textOperator("cc", MailFieldCc),
+		textOperator("bcc", MailFieldBcc),
+		textOperator("subject", MailFieldSubject),
+		dateOperator("after", MailFieldReceiveDate, GreaterEq),
+		dateOperator("before", MailFieldReceiveDate, LessThan),
+		sizeOperator("larger", MailFieldSize, GreaterThan),
+		sizeOperator("smaller", MailFieldSize, LessThan),
+		flagOperator("has", "attachment", MailFieldHasAttachment, true),
+		flagOperator("is", "read", MailFieldIsSeen, true),
+		flagOperator("is", "unread", MailFieldIsSeen, false),
+		flagOperator("is", "flagged", MailFieldIsFlagged, true),
+		flagOperator("is", "unflagged", MailFieldIsFlagged, false),
+		flagOperator("is", "answered", MailFieldIsAnswered, true),
+		flagOperator("is", "forwarded", MailFieldIsForwarded, true),
+		flagOperator("is", "draft", MailFieldIsDraft, true),
+		flagOperator("is", "junk", MailFieldIsJunk, true),
+	},
+}
+
+// ContactSearch - search expression of contacts, e.g.
+//	name:alice company:"Example Ltd" email:example.com
+// Operators: name, first, last, nick, email, phone, company, department, profession, comment, is:gal.
+// Words without operator are searched in the main text fields.
+var ContactSearch = &SearchGrammar{
+	name: "contact",
+	text: ContactFieldQuickSearch,
+	operators: []searchOperator{
+		textOperator("name", ContactFieldCommonName),
+		textOperator("first", ContactFieldFirstName),
+		textOperator("last", ContactFieldSurName),
+		textOperator("nick", ContactFieldNickName),
+		textOperator("email", ContactFieldEmailAddresses),
+		textOperator("phone", ContactFieldPhoneNumbers),
+		textOperator("company", ContactFieldCompanyName),
+		textOperator("department", ContactFieldDepartmentName),
+		textOperator("profession", ContactFieldProfession),
+		textOperator("comment", ContactFieldComment),
+		flagOperator("is", "gal", ContactFieldIsGalContact, true),
+	},
+}
+
+// EventSearch - search expression of events, e.g.
+//	summary:standup location:"Room 1" after:2026-01-01 is:allday
+// Operators: summary, location, description, after, before (start of the event, date as 2006-01-02 or RFC 3339),
+// is:allday, is:private, is:cancelled.
+// Words without operator are searched in the main text fields.
+var EventSearch = &SearchGrammar{
+	name: "event",
+	text: EventFieldQuickSearch,
+	operators: []searchOperator{
+		textOperator("summary", EventFieldSummary),
+		textOperator("location", EventFieldLocation),
+		textOperator("description", EventFieldDescription),
+		dateOperator("after", EventFieldStart, GreaterEq),
+		dateOperator("before", EventFieldStart, LessThan),
+		flagOperator("is", "allday", EventFieldIsAllDay, true),
+		flagOperator("is", "private", EventFieldIsPrivate, true),
+		flagOperator("is", "cancelled", EventFieldIsCancelled, true),
+	},
+}
+
+// Parse returns the query of the search expression.
+// The terms are combined by And, or by Or if they are separated by OR; both cannot be mixed.
+func (g *SearchGrammar) Parse(expression string) (SearchQuery, error) {
+	tokens, err := splitSearch(expression)
+	if err != nil {
+		return SearchQuery{}, err
+	}
+	var terms []string
+	combining, separator := LogicalOperator(""), LogicalOperator("")
+	for _, token := range tokens {
+		if operator, ok := searchKeywords[token]; ok {
+			if len(terms) == 0 || separator != "" {
+				return SearchQuery{}, fmt.Errorf("%w: %s without term", ErrInvalidSearch, token)
+			}
+			separator = operator
+			continue
+		}
+		if len(terms) > 0 {
+			if separator == "" {
+				separator = And
+			}
+			if combining != "" && combining != separator {
+				return SearchQuery{}, fmt.Errorf("%w: terms cannot be combined by both AND and OR", ErrInvalidSearch)
+			}
+			combining = separator
+		}
+		separator = ""
+		terms = append(terms, token)
+	}
+	if separator != "" {
+		return SearchQuery{}, fmt.Errorf("%w: %s without term", ErrInvalidSearch, strings.ToUpper(string(separator)))
+	}
+	builder := Query()
+	add := builder.Where
+	for _, term := range terms {
+		field, comparator, value, err := g.parseTerm(term)
+		if err != nil {
+			return SearchQuery{}, err
+		}
+		add(field, comparator, value)
+		add = builder.And
+		if combining == Or {
+			add = builder.Or
+		}
+	}
+	return builder.Build()
+}
+
+// Format returns the search expression of the query, only the conditions of the query are used
+func (g *SearchGrammar) Format(query SearchQuery) (string, error) {
+	terms := make([]string, 0, len(query.Conditions))
+	for _, condition := range query.Conditions {
+		term, err := g.formatCondition(condition)
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, term)
+	}
+	separator := " "
+	if query.Combining == Or {
+		separator = " OR "
+	}
+	return strings.Join(terms, separator), nil
+}
+
+// parseTerm returns the condition of one term, e.g. "from:alice"
+func (g *SearchGrammar) parseTerm(term string) (Field, CompareOperator, string, error) {
+	if strings.HasPrefix(term, "-") {
+		return nil, "", "", fmt.Errorf("%w: negation of %q is not supported", ErrInvalidSearch, term)
+	}
+	name, value, ok := cutOperator(term)
+	if !ok {
+		return g.text, Like, likeValue(unquote(term)), nil
+	}
+	value = unquote(value)
+	if value == "" {
+		return nil, "", "", fmt.Errorf("%w: operator %s without value", ErrInvalidSearch, name)
+	}
+	known := false
+	for _, operator := range g.operators {
+		if operator.name != name {
+			continue
+		}
+		known = true
+		if operator.word == "" || strings.EqualFold(operator.word, value) {
+			return operator.parse(value)
+		}
+	}
+	if known {
+		return nil, "", "", fmt.Errorf("%w: unknown value %q of operator %s in %s search", ErrInvalidSearch, value, name, g.name)
+	}
+	return nil, "", "", fmt.Errorf("%w: unknown operator %s in %s search", ErrInvalidSearch, name, g.name)
+}
+
+func (g *SearchGrammar) formatCondition(condition SubCondition) (string, error) {
+	if condition.FieldName == g.text.fieldName() && condition.Comparator == Like {
+		if value, ok := unlikeValue(condition.Value); ok {
+			return quote(value, true)
+		}
+	}
+	for _, operator := range g.operators {
+		value, ok := operator.format(condition)
+		if !ok {
+			continue
+		}
+		value, err := quote(value, false)
+		if err != nil {
+			return "", err
+		}
+		return operator.name + ":" + value, nil
+	}
+	return "", fmt.Errorf("%w: condition %s %s %q has no text form in %s search",
+		ErrInvalidSearch, condition.FieldName, condition.Comparator, condition.Value, g.name)
+}
+
+// textOperator - operator searching the value in the field, e.g. "subject:report"
+func textOperator(name string, field Field) searchOperator {
+	return searchOperator{
+		name: name,
+		parse: func(value string) (Field, CompareOperator, string, error) {
+			return field, Like, likeValue(value), nil
+		},
+		format: func(condition SubCondition) (string, bool) {
+			if condition.FieldName != field.fieldName() || condition.Comparator != Like {
+				return "", false
+			}
+			return unlikeValue(condition.Value)
+		},
+	}
+}
+
+// dateOperator - operator comparing the date field, e.g. "after:2026-01-01"
+func dateOperator(name string, field Field, comparator CompareOperator) searchOperator {
+	return searchOperator{
+		name: name,
+		parse: func(value string) (Field, CompareOperator, string, error) {
+			t, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				t, err = time.Parse(time.RFC3339, value)
+			}
+			if err != nil {
+				return nil, "", "", fmt.Errorf("%w: %s:%s is not a date", ErrInvalidSearch, name, value)
+			}
+			return field, comparator, string(UtcDateTimeFromTime(t)), nil
+		},
+		format: func(condition SubCondition) (string, bool) {
+			if condition.FieldName != field.fieldName() || condition.Comparator != comparator {
+				return "", false
+			}
+			t, err := UtcDateTime(condition.Value).Time()
+			if err != nil || t.IsZero() {
+				return "", false
+			}
+			if t.Equal(t.Truncate(24 * time.Hour)) {
+				return t.Format("2006-01-02"), true
+			}
+			return t.Format(time.RFC3339), true
+		},
+	}
+}
+
+// sizeOperator - operator comparing the size in bytes, e.g. "larger:5M"
+func sizeOperator(name string, field Field, comparator CompareOperator) searchOperator {
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}}
+	return searchOperator{
+		name: name,
+		parse: func(value string) (Field, CompareOperator, string, error) {
+			number, multiplier := strings.ToUpper(value), int64(1)
+			for _, unit := range units {
+				if strings.HasSuffix(number, unit.suffix) {
+					number, multiplier = strings.TrimSuffix(number, unit.suffix), unit.size
+					break
+				}
+			}
+			size, err := strconv.ParseInt(number, 10, 64)
+			if err != nil || size < 0 {
+				return nil, "", "", fmt.Errorf("%w: %s:%s is not a size", ErrInvalidSearch, name, value)
+			}
+			return field, comparator, strconv.FormatInt(size*multiplier, 10), nil
+		},
+		format: func(condition SubCondition) (string, bool) {
+			if condition.FieldName != field.fieldName() || condition.Comparator != comparator {
+				return "", false
+			}
+			size, err := strconv.ParseInt(condition.Value, 10, 64)
+			if err != nil {
+				return "", false
+			}
+			for _, unit := range units {
+				if size != 0 && size%unit.size == 0 {
+					return strconv.FormatInt(size/unit.size, 10) + unit.suffix, true
+				}
+			}
+			return condition.Value, true
+		},
+	}
+}
+
+// flagOperator - operator with the only value checking the boolean field, e.g. "is:unread"
+func flagOperator(name, word string, field Field, value bool) searchOperator {
+	text := strconv.FormatBool(value)
+	return searchOperator{
+		name: name,
+		word: word,
+		parse: func(string) (Field, CompareOperator, string, error) {
+			return field, Eq, text, nil
+		},
+		format: func(condition SubCondition) (string, bool) {
+			if condition.FieldName != field.fieldName() || condition.Comparator != Eq || !strings.EqualFold(condition.Value, text) {
+				return "", false
+			}
+			return word, true
+		},
+	}
+}
+
+// splitSearch splits the expression to terms separated by spaces, quoted parts may contain spaces
+func splitSearch(expression string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, r := range expression {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidSearch)
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
+
+// cutOperator splits the term "name:value" if the name contains letters only
+func cutOperator(term string) (string, string, bool) {
+	i := strings.IndexByte(term, ':')
+	if i <= 0 {
+		return "", "", false
+	}
+	for _, r := range term[:i] {
+		if !unicode.IsLetter(r) {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(term[:i]), term[i+1:], true
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// quote returns the value quoted if it contains spaces or could be read as a keyword or an operator
+func quote(value string, word bool) (string, error) {
+	if strings.Contains(value, `"`) {
+		return "", fmt.Errorf("%w: value %s cannot be quoted", ErrInvalidSearch, value)
+	}
+	_, _, operator := cutOperator(value)
+	if value == "" || strings.IndexFunc(value, unicode.IsSpace) >= 0 || word && (operator || strings.HasPrefix(value, "-")) ||
+		searchKeywords[value] != "" {
+		return `"` + value + `"`, nil
+	}
+	return value, nil
+}
+
+// likeValue returns the value of Like condition matching the text anywhere
+func likeValue(text string) string {
+	return "%" + text + "%"
+}
+
+// unlikeValue is the reverse of likeValue
+func unlikeValue(value string) (string, bool) {
+	if len(value) < 2 || !strings.HasPrefix(value, "%") || !strings.HasSuffix(value, "%") {
+		return "", false
+	}
+	return value[1 : len(value)-1], true
+}
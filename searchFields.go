@@ -0,0 +1,200 @@
+package webmail
+
+// MailField - name of the field of e-mails, see MailsGet
+type MailField string
+
+const (
+	MailFieldQuickSearch      MailField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	MailFieldId               MailField = "id"
+	MailFieldFolderId         MailField = "folderId"
+	MailFieldWatermark        MailField = "watermark"
+	MailFieldFrom             MailField = "from"
+	MailFieldSender           MailField = "sender"
+	MailFieldTo               MailField = "to"
+	MailFieldCc               MailField = "cc"
+	MailFieldBcc              MailField = "bcc"
+	MailFieldSendDate         MailField = "sendDate"
+	MailFieldReceiveDate      MailField = "receiveDate"
+	MailFieldModifiedDate     MailField = "modifiedDate"
+	MailFieldReplyTo          MailField = "replyTo"
+	MailFieldNotificationTo   MailField = "notificationTo"
+	MailFieldSubject          MailField = "subject"
+	MailFieldPriority         MailField = "priority"
+	MailFieldSize             MailField = "size"
+	MailFieldIsSeen           MailField = "isSeen"
+	MailFieldIsAnswered       MailField = "isAnswered"
+	MailFieldIsFlagged        MailField = "isFlagged"
+	MailFieldIsForwarded      MailField = "isForwarded"
+	MailFieldIsJunk           MailField = "isJunk"
+	MailFieldIsMDNSent        MailField = "isMDNSent"
+	MailFieldShowExternal     MailField = "showExternal"
+	MailFieldRequestDSN       MailField = "requestDSN"
+	MailFieldHasAttachment    MailField = "hasAttachment"
+	MailFieldIsDraft          MailField = "isDraft"
+	MailFieldIsReadOnly       MailField = "isReadOnly"
+	MailFieldSignInfo         MailField = "signInfo"
+	MailFieldEncryptInfo      MailField = "encryptInfo"
+	MailFieldDisplayableParts MailField = "displayableParts"
+	MailFieldAttachments      MailField = "attachments"
+	MailFieldHeaders          MailField = "headers"
+)
+
+func (f MailField) fieldName() string { return string(f) }
+
+func (f MailField) entity() string { return "Mail" }
+
+// ContactField - name of the field of contacts, see ContactsGet
+type ContactField string
+
+const (
+	ContactFieldQuickSearch     ContactField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	ContactFieldId              ContactField = "id"
+	ContactFieldFolderId        ContactField = "folderId"
+	ContactFieldWatermark       ContactField = "watermark"
+	ContactFieldType            ContactField = "type"
+	ContactFieldCommonName      ContactField = "commonName"
+	ContactFieldFirstName       ContactField = "firstName"
+	ContactFieldMiddleName      ContactField = "middleName"
+	ContactFieldSurName         ContactField = "surName"
+	ContactFieldTitleBefore     ContactField = "titleBefore"
+	ContactFieldTitleAfter      ContactField = "titleAfter"
+	ContactFieldNickName        ContactField = "nickName"
+	ContactFieldPhoneNumbers    ContactField = "phoneNumbers"
+	ContactFieldEmailAddresses  ContactField = "emailAddresses"
+	ContactFieldPostalAddresses ContactField = "postalAddresses"
+	ContactFieldUrls            ContactField = "urls"
+	ContactFieldBirthDay        ContactField = "birthDay"
+	ContactFieldAnniversary     ContactField = "anniversary"
+	ContactFieldCompanyName     ContactField = "companyName"
+	ContactFieldDepartmentName  ContactField = "departmentName"
+	ContactFieldProfession      ContactField = "profession"
+	ContactFieldManagerName     ContactField = "managerName"
+	ContactFieldAssistantName   ContactField = "assistantName"
+	ContactFieldComment         ContactField = "comment"
+	ContactFieldImAddress       ContactField = "IMAddress"
+	ContactFieldPhoto           ContactField = "photo"
+	ContactFieldCategories      ContactField = "categories"
+	ContactFieldCertSourceId    ContactField = "certSourceId"
+	ContactFieldIsGalContact    ContactField = "isGalContact"
+)
+
+func (f ContactField) fieldName() string { return string(f) }
+
+func (f ContactField) entity() string { return "Contact" }
+
+// EventField - name of the field of events, see EventsGet
+type EventField string
+
+const (
+	EventFieldQuickSearch   EventField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	EventFieldId            EventField = "id"
+	EventFieldFolderId      EventField = "folderId"
+	EventFieldWatermark     EventField = "watermark"
+	EventFieldAccess        EventField = "access"
+	EventFieldSummary       EventField = "summary"
+	EventFieldLocation      EventField = "location"
+	EventFieldDescription   EventField = "description"
+	EventFieldLabel         EventField = "label"
+	EventFieldCategories    EventField = "categories"
+	EventFieldStart         EventField = "start"
+	EventFieldEnd           EventField = "end"
+	EventFieldTravelMinutes EventField = "travelMinutes"
+	EventFieldFreeBusy      EventField = "freeBusy"
+	EventFieldIsPrivate     EventField = "isPrivate"
+	EventFieldIsAllDay      EventField = "isAllDay"
+	EventFieldPriority      EventField = "priority"
+	EventFieldRule          EventField = "rule"
+	EventFieldAttendees     EventField = "attendees"
+	EventFieldReminder      EventField = "reminder"
+	EventFieldIsCancelled   EventField = "isCancelled"
+)
+
+func (f EventField) fieldName() string { return string(f) }
+
+func (f EventField) entity() string { return "Event" }
+
+// OccurrenceField - name of the field of occurrences, see OccurrencesGet
+type OccurrenceField string
+
+const (
+	OccurrenceFieldQuickSearch   OccurrenceField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	OccurrenceFieldId            OccurrenceField = "id"
+	OccurrenceFieldEventId       OccurrenceField = "eventId"
+	OccurrenceFieldFolderId      OccurrenceField = "folderId"
+	OccurrenceFieldWatermark     OccurrenceField = "watermark"
+	OccurrenceFieldAccess        OccurrenceField = "access"
+	OccurrenceFieldSummary       OccurrenceField = "summary"
+	OccurrenceFieldLocation      OccurrenceField = "location"
+	OccurrenceFieldDescription   OccurrenceField = "description"
+	OccurrenceFieldLabel         OccurrenceField = "label"
+	OccurrenceFieldCategories    OccurrenceField = "categories"
+	OccurrenceFieldStart         OccurrenceField = "start"
+	OccurrenceFieldEnd           OccurrenceField = "end"
+	OccurrenceFieldTravelMinutes OccurrenceField = "travelMinutes"
+	OccurrenceFieldFreeBusy      OccurrenceField = "freeBusy"
+	OccurrenceFieldIsPrivate     OccurrenceField = "isPrivate"
+	OccurrenceFieldIsAllDay      OccurrenceField = "isAllDay"
+	OccurrenceFieldPriority      OccurrenceField = "priority"
+	OccurrenceFieldRule          OccurrenceField = "rule"
+	OccurrenceFieldAttendees     OccurrenceField = "attendees"
+	OccurrenceFieldReminder      OccurrenceField = "reminder"
+	OccurrenceFieldIsException   OccurrenceField = "isException"
+	OccurrenceFieldHasReminder   OccurrenceField = "hasReminder"
+	OccurrenceFieldIsRecurrent   OccurrenceField = "isRecurrent"
+	OccurrenceFieldIsCancelled   OccurrenceField = "isCancelled"
+	OccurrenceFieldSeqNumber     OccurrenceField = "seqNumber"
+	OccurrenceFieldModification  OccurrenceField = "modification"
+)
+
+func (f OccurrenceField) fieldName() string { return string(f) }
+
+func (f OccurrenceField) entity() string { return "Occurrence" }
+
+// TaskField - name of the field of tasks, see TasksGet
+type TaskField string
+
+const (
+	TaskFieldQuickSearch TaskField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	TaskFieldId          TaskField = "id"
+	TaskFieldFolderId    TaskField = "folderId"
+	TaskFieldWatermark   TaskField = "watermark"
+	TaskFieldAccess      TaskField = "access"
+	TaskFieldSummary     TaskField = "summary"
+	TaskFieldLocation    TaskField = "location"
+	TaskFieldDescription TaskField = "description"
+	TaskFieldStatus      TaskField = "status"
+	TaskFieldStart       TaskField = "start"
+	TaskFieldDue         TaskField = "due"
+	TaskFieldEnd         TaskField = "end"
+	TaskFieldDone        TaskField = "done"
+	TaskFieldPriority    TaskField = "priority"
+	TaskFieldRule        TaskField = "rule"
+	TaskFieldAttendees   TaskField = "attendees"
+	TaskFieldReminder    TaskField = "reminder"
+	TaskFieldSortOrder   TaskField = "sortOrder"
+	TaskFieldIsPrivate   TaskField = "isPrivate"
+	TaskFieldIsCancelled TaskField = "isCancelled"
+)
+
+func (f TaskField) fieldName() string { return string(f) }
+
+func (f TaskField) entity() string { return "Task" }
+
+// NoteField - name of the field of notes, see NotesGet
+type NoteField string
+
+const (
+	NoteFieldQuickSearch NoteField = "QUICKSEARCH" // matches the value in the main text fields, use with Like
+	NoteFieldId          NoteField = "id"
+	NoteFieldFolderId    NoteField = "folderId"
+	NoteFieldWatermark   NoteField = "watermark"
+	NoteFieldColor       NoteField = "color"
+	NoteFieldText        NoteField = "text"
+	NoteFieldPosition    NoteField = "position"
+	NoteFieldCreateDate  NoteField = "createDate"
+	NoteFieldModifyDate  NoteField = "modifyDate"
+)
+
+func (f NoteField) fieldName() string { return string(f) }
+
+func (f NoteField) entity() string { return "Note" }
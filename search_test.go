@@ -0,0 +1,87 @@
+package webmail
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSearchGrammar_Parse(t *testing.T) {
+	query, err := MailSearch.Parse(`from:alice subject:"Q3 report" after:2026-01-01 has:attachment is:unread larger:5M invoice`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SubConditionList{
+		{FieldName: "from", Comparator: Like, Value: "%alice%"},
+		{FieldName: "subject", Comparator: Like, Value: "%Q3 report%"},
+		{FieldName: "receiveDate", Comparator: GreaterEq, Value: "20260101T000000+0000"},
+		{FieldName: "hasAttachment", Comparator: Eq, Value: "true"},
+		{FieldName: "isSeen", Comparator: Eq, Value: "false"},
+		{FieldName: "size", Comparator: GreaterThan, Value: "5242880"},
+		{FieldName: "QUICKSEARCH", Comparator: Like, Value: "%invoice%"},
+	}
+	if !reflect.DeepEqual(query.Conditions, want) || query.Combining != And {
+		t.Errorf("got %+v", query)
+	}
+
+	query, err = ContactSearch.Parse(`name:alice OR email:example.com`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(query.Conditions) != 2 || query.Combining != Or || query.Conditions[1].FieldName != "emailAddresses" {
+		t.Errorf("got %+v", query)
+	}
+}
+
+func TestSearchGrammar_ParseErrors(t *testing.T) {
+	tests := []string{
+		`form:alice`,
+		`is:important`,
+		`after:yesterday`,
+		`larger:5X`,
+		`subject:"Q3 report`,
+		`from:alice OR bob carol`,
+		`OR alice`,
+		`alice OR`,
+		`-from:alice`,
+		`from:`,
+	}
+	for _, expression := range tests {
+		if _, err := MailSearch.Parse(expression); !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("%s: got %v, want ErrInvalidSearch", expression, err)
+		}
+	}
+	if _, err := EventSearch.Parse(`from:alice`); err == nil || err.Error() != "invalid search expression: unknown operator from in event search" {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestSearchGrammar_Format(t *testing.T) {
+	tests := []struct {
+		grammar    *SearchGrammar
+		expression string
+	}{
+		{MailSearch, `from:alice subject:"Q3 report" after:2026-01-01 before:2026-02-01T10:30:00Z has:attachment is:unread smaller:100K invoice`},
+		{MailSearch, `subject:report OR subject:"OR" OR "a:b"`},
+		{EventSearch, `location:"Room 1" is:allday`},
+		{ContactSearch, ``},
+	}
+	for _, test := range tests {
+		query, err := test.grammar.Parse(test.expression)
+		if err != nil {
+			t.Errorf("%s: %v", test.expression, err)
+			continue
+		}
+		got, err := test.grammar.Format(query)
+		if err != nil {
+			t.Errorf("%s: %v", test.expression, err)
+		}
+		if got != test.expression {
+			t.Errorf("got %s, want %s", got, test.expression)
+		}
+	}
+	query := SearchQuery{Conditions: SubConditionList{{FieldName: "subject", Comparator: NotEq, Value: "x"}}}
+	if _, err := MailSearch.Format(query); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("got %v, want ErrInvalidSearch", err)
+	}
+}
@@ -0,0 +1,518 @@
+// Code generated by webmailgen from schema/session.json. DO NOT EDIT.
+
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SessionCanUserChangePasswordResult - result of Session.canUserChangePassword
+type SessionCanUserChangePasswordResult struct {
+	IsEligible bool `json:"isEligible"` // is set to true as long as user is eligible
+}
+
+// SessionCanUserChangePassword - to change his/her password.
+// Return
+//
+//	isEligible - is set to true as long as user is eligible
+func (c *ClientConnection) SessionCanUserChangePassword() (bool, error) {
+	return c.SessionCanUserChangePasswordContext(context.Background())
+}
+
+// SessionCanUserChangePasswordContext - the same as SessionCanUserChangePassword, but the call is bound to ctx.
+func (c *ClientConnection) SessionCanUserChangePasswordContext(ctx context.Context) (bool, error) {
+	data, err := c.CallRawContext(ctx, "Session.canUserChangePassword", nil)
+	if err != nil {
+		return false, err
+	}
+	result := struct {
+		Result SessionCanUserChangePasswordResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.IsEligible, err
+}
+
+// SessionGetAvailableTimeZonesResult - result of Session.getAvailableTimeZones
+type SessionGetAvailableTimeZonesResult struct {
+	Zones StringList `json:"zones"` // list of time zones
+}
+
+// SessionGetAvailableTimeZones - Get list of all available time zones.
+// Return
+//
+//	zones - list of time zones
+func (c *ClientConnection) SessionGetAvailableTimeZones() (StringList, error) {
+	return c.SessionGetAvailableTimeZonesContext(context.Background())
+}
+
+// SessionGetAvailableTimeZonesContext - the same as SessionGetAvailableTimeZones, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetAvailableTimeZonesContext(ctx context.Context) (StringList, error) {
+	data, err := c.CallRawContext(ctx, "Session.getAvailableTimeZones", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetAvailableTimeZonesResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Zones, err
+}
+
+// SessionGetAvailableLanguagesResult - result of Session.getAvailableLanguages
+type SessionGetAvailableLanguagesResult struct {
+	Languages LangDescriptionList `json:"languages"`
+}
+
+// SessionGetAvailableLanguages - Get list of all languages supported by server.
+// Return
+//
+//	languages
+func (c *ClientConnection) SessionGetAvailableLanguages() (LangDescriptionList, error) {
+	return c.SessionGetAvailableLanguagesContext(context.Background())
+}
+
+// SessionGetAvailableLanguagesContext - the same as SessionGetAvailableLanguages, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetAvailableLanguagesContext(ctx context.Context) (LangDescriptionList, error) {
+	data, err := c.CallRawContext(ctx, "Session.getAvailableLanguages", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetAvailableLanguagesResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Languages, err
+}
+
+// SessionGetOutOfOfficeResult - result of Session.getOutOfOffice
+type SessionGetOutOfOfficeResult struct {
+	Settings OutOfOfficeSettings `json:"settings"` // details
+}
+
+// SessionGetOutOfOffice - Obtain the Auto Reply settings
+// Return
+//
+//	settings - details
+func (c *ClientConnection) SessionGetOutOfOffice() (*OutOfOfficeSettings, error) {
+	return c.SessionGetOutOfOfficeContext(context.Background())
+}
+
+// SessionGetOutOfOfficeContext - the same as SessionGetOutOfOffice, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetOutOfOfficeContext(ctx context.Context) (*OutOfOfficeSettings, error) {
+	data, err := c.CallRawContext(ctx, "Session.getOutOfOffice", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetOutOfOfficeResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.Settings, err
+}
+
+// SessionGetQuotaInformationResult - result of Session.getQuotaInformation
+type SessionGetQuotaInformationResult struct {
+	QuotaInfo QuotaInfo `json:"quotaInfo"`
+}
+
+// SessionGetQuotaInformation - Obtain iformations about quota of current user.
+// Return
+//
+//	quotaInfo
+func (c *ClientConnection) SessionGetQuotaInformation() (*QuotaInfo, error) {
+	return c.SessionGetQuotaInformationContext(context.Background())
+}
+
+// SessionGetQuotaInformationContext - the same as SessionGetQuotaInformation, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetQuotaInformationContext(ctx context.Context) (*QuotaInfo, error) {
+	data, err := c.CallRawContext(ctx, "Session.getQuotaInformation", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetQuotaInformationResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.QuotaInfo, err
+}
+
+// SessionGetSettingsResult - result of Session.getSettings
+type SessionGetSettingsResult struct {
+	Settings jsonstring `json:"settings"` // WAM settings
+}
+
+// SessionGetSettings - Obtain currently logged user's settings.
+//
+//	query
+//
+// Return
+//
+//	settings - WAM settings
+func (c *ClientConnection) SessionGetSettings(query SettingQuery) (*jsonstring, error) {
+	return c.SessionGetSettingsContext(context.Background(), query)
+}
+
+// SessionGetSettingsContext - the same as SessionGetSettings, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetSettingsContext(ctx context.Context, query SettingQuery) (*jsonstring, error) {
+	params := struct {
+		Query SettingQuery `json:"query"`
+	}{query}
+	data, err := c.CallRawContext(ctx, "Session.getSettings", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetSettingsResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.Settings, err
+}
+
+// SessionGetSpamSettingsResult - result of Session.getSpamSettings
+type SessionGetSpamSettingsResult struct {
+	Settings SpamSettings `json:"settings"` // details
+}
+
+// SessionGetSpamSettings - Obtain the spam settings
+// Return
+//
+//	settings - details
+func (c *ClientConnection) SessionGetSpamSettings() (*SpamSettings, error) {
+	return c.SessionGetSpamSettingsContext(context.Background())
+}
+
+// SessionGetSpamSettingsContext - the same as SessionGetSpamSettings, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetSpamSettingsContext(ctx context.Context) (*SpamSettings, error) {
+	data, err := c.CallRawContext(ctx, "Session.getSpamSettings", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetSpamSettingsResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.Settings, err
+}
+
+// SessionGetUserVoiceUrlResult - result of Session.getUserVoiceUrl
+type SessionGetUserVoiceUrlResult struct {
+	AccessUrl string `json:"accessUrl"` // URL for access to UserVoice
+}
+
+// SessionGetUserVoiceUrl - Obtain URL for users' access to UserVoice
+// Return
+//
+//	accessUrl - URL for access to UserVoice
+func (c *ClientConnection) SessionGetUserVoiceUrl() (string, error) {
+	return c.SessionGetUserVoiceUrlContext(context.Background())
+}
+
+// SessionGetUserVoiceUrlContext - the same as SessionGetUserVoiceUrl, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetUserVoiceUrlContext(ctx context.Context) (string, error) {
+	data, err := c.CallRawContext(ctx, "Session.getUserVoiceUrl", nil)
+	if err != nil {
+		return "", err
+	}
+	result := struct {
+		Result SessionGetUserVoiceUrlResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.AccessUrl, err
+}
+
+// Logout - [KLogoutMethod]
+func (c *ClientConnection) Logout() error {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext - the same as Logout, but the call is bound to ctx.
+func (c *ClientConnection) LogoutContext(ctx context.Context) error {
+	_, err := c.CallRawContext(ctx, "Session.logout", nil)
+	return err
+}
+
+// SessionSetOutOfOffice - Set the Auto Reply settings
+//
+//	settings - details
+func (c *ClientConnection) SessionSetOutOfOffice(settings OutOfOfficeSettings) error {
+	return c.SessionSetOutOfOfficeContext(context.Background(), settings)
+}
+
+// SessionSetOutOfOfficeContext - the same as SessionSetOutOfOffice, but the call is bound to ctx.
+func (c *ClientConnection) SessionSetOutOfOfficeContext(ctx context.Context, settings OutOfOfficeSettings) error {
+	params := struct {
+		Settings OutOfOfficeSettings `json:"settings"`
+	}{settings}
+	_, err := c.CallRawContext(ctx, "Session.setOutOfOffice", params)
+	return err
+}
+
+// SessionSetPassword - Change password of current user.
+//
+//	currentPassword - current users' password
+//	newPassword - new users' password
+func (c *ClientConnection) SessionSetPassword(currentPassword string, newPassword string) error {
+	return c.SessionSetPasswordContext(context.Background(), currentPassword, newPassword)
+}
+
+// SessionSetPasswordContext - the same as SessionSetPassword, but the call is bound to ctx.
+func (c *ClientConnection) SessionSetPasswordContext(ctx context.Context, currentPassword string, newPassword string) error {
+	params := struct {
+		CurrentPassword string `json:"currentPassword"`
+		NewPassword     string `json:"newPassword"`
+	}{currentPassword, newPassword}
+	_, err := c.CallRawContext(ctx, "Session.setPassword", params)
+	return err
+}
+
+// SessionSetSettings - Set settings of the currently logged user.
+//
+//	settings - WAM settings
+func (c *ClientConnection) SessionSetSettings(settings jsonstring) error {
+	return c.SessionSetSettingsContext(context.Background(), settings)
+}
+
+// SessionSetSettingsContext - the same as SessionSetSettings, but the call is bound to ctx.
+func (c *ClientConnection) SessionSetSettingsContext(ctx context.Context, settings jsonstring) error {
+	params := struct {
+		Settings jsonstring `json:"settings"`
+	}{settings}
+	_, err := c.CallRawContext(ctx, "Session.setSettings", params)
+	return err
+}
+
+// SessionSetSpamSettings - Set the spam settings
+//
+//	settings - details
+func (c *ClientConnection) SessionSetSpamSettings(settings SpamSettings) error {
+	return c.SessionSetSpamSettingsContext(context.Background(), settings)
+}
+
+// SessionSetSpamSettingsContext - the same as SessionSetSpamSettings, but the call is bound to ctx.
+func (c *ClientConnection) SessionSetSpamSettingsContext(ctx context.Context, settings SpamSettings) error {
+	params := struct {
+		Settings SpamSettings `json:"settings"`
+	}{settings}
+	_, err := c.CallRawContext(ctx, "Session.setSpamSettings", params)
+	return err
+}
+
+// SessionSetUserInfo - Set user details.
+//
+//	userDetails - details about the currently logged user
+func (c *ClientConnection) SessionSetUserInfo(userDetails UserInfo) error {
+	return c.SessionSetUserInfoContext(context.Background(), userDetails)
+}
+
+// SessionSetUserInfoContext - the same as SessionSetUserInfo, but the call is bound to ctx.
+func (c *ClientConnection) SessionSetUserInfoContext(ctx context.Context, userDetails UserInfo) error {
+	params := struct {
+		UserDetails UserInfo `json:"userDetails"`
+	}{userDetails}
+	_, err := c.CallRawContext(ctx, "Session.setUserInfo", params)
+	return err
+}
+
+// SessionWhoAmIResult - result of Session.whoAmI
+type SessionWhoAmIResult struct {
+	UserDetails UserInfo `json:"userDetails"` // details about the currently logged user
+}
+
+// SessionWhoAmI - Determines the currently logged user (caller).
+// Return
+//
+//	userDetails - details about the currently logged user
+func (c *ClientConnection) SessionWhoAmI() (*UserInfo, error) {
+	return c.SessionWhoAmIContext(context.Background())
+}
+
+// SessionWhoAmIContext - the same as SessionWhoAmI, but the call is bound to ctx.
+func (c *ClientConnection) SessionWhoAmIContext(ctx context.Context) (*UserInfo, error) {
+	data, err := c.CallRawContext(ctx, "Session.whoAmI", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionWhoAmIResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return &result.Result.UserDetails, err
+}
+
+// SessionGetMobileDeviceListResult - result of Session.getMobileDeviceList
+type SessionGetMobileDeviceListResult struct {
+	List       MobileDeviceList `json:"list"`       // mobile devices of given user
+	TotalItems int              `json:"totalItems"` // number of mobile devices found for given user
+}
+
+// SessionGetMobileDeviceList - Obtain a list of mobile devices of given user.
+//
+//	query - query attributes and limits
+//
+// Return
+//
+//	list - mobile devices of given user
+//	totalItems - number of mobile devices found for given user
+func (c *ClientConnection) SessionGetMobileDeviceList(query SearchQuery) (MobileDeviceList, int, error) {
+	return c.SessionGetMobileDeviceListContext(context.Background(), query)
+}
+
+// SessionGetMobileDeviceListContext - the same as SessionGetMobileDeviceList, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetMobileDeviceListContext(ctx context.Context, query SearchQuery) (MobileDeviceList, int, error) {
+	query = addMissedParametersToSearchQuery(query)
+	params := struct {
+		Query SearchQuery `json:"query"`
+	}{query}
+	data, err := c.CallRawContext(ctx, "Session.getMobileDeviceList", params)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := struct {
+		Result SessionGetMobileDeviceListResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, result.Result.TotalItems, err
+}
+
+// SessionRemoveMobileDevice - Remove mobile device from the list of user's mobile devices.
+//
+//	deviceId - ID of user's mobile device to be removed
+func (c *ClientConnection) SessionRemoveMobileDevice(deviceId string) error {
+	return c.SessionRemoveMobileDeviceContext(context.Background(), deviceId)
+}
+
+// SessionRemoveMobileDeviceContext - the same as SessionRemoveMobileDevice, but the call is bound to ctx.
+func (c *ClientConnection) SessionRemoveMobileDeviceContext(ctx context.Context, deviceId string) error {
+	params := struct {
+		DeviceId string `json:"deviceId"`
+	}{deviceId}
+	_, err := c.CallRawContext(ctx, "Session.removeMobileDevice", params)
+	return err
+}
+
+// SessionWipeMobileDevice - Wipe user's mobile device.
+//
+//	deviceId - ID of user's mobile device to be wiped
+//	password - password of current user
+func (c *ClientConnection) SessionWipeMobileDevice(deviceId string, password string) error {
+	return c.SessionWipeMobileDeviceContext(context.Background(), deviceId, password)
+}
+
+// SessionWipeMobileDeviceContext - the same as SessionWipeMobileDevice, but the call is bound to ctx.
+func (c *ClientConnection) SessionWipeMobileDeviceContext(ctx context.Context, deviceId string, password string) error {
+	params := struct {
+		DeviceId string `json:"deviceId"`
+		Password string `json:"password"`
+	}{deviceId, password}
+	_, err := c.CallRawContext(ctx, "Session.wipeMobileDevice", params)
+	return err
+}
+
+// SessionCancelWipeMobileDevice - Cancel wiping of user's mobile device.
+//
+//	deviceId - ID of user's mobile device to cancel wipe
+func (c *ClientConnection) SessionCancelWipeMobileDevice(deviceId string) error {
+	return c.SessionCancelWipeMobileDeviceContext(context.Background(), deviceId)
+}
+
+// SessionCancelWipeMobileDeviceContext - the same as SessionCancelWipeMobileDevice, but the call is bound to ctx.
+func (c *ClientConnection) SessionCancelWipeMobileDeviceContext(ctx context.Context, deviceId string) error {
+	params := struct {
+		DeviceId string `json:"deviceId"`
+	}{deviceId}
+	_, err := c.CallRawContext(ctx, "Session.cancelWipeMobileDevice", params)
+	return err
+}
+
+// SessionGetSignatureImageListResult - result of Session.getSignatureImageList
+type SessionGetSignatureImageListResult struct {
+	List ImageList `json:"list"`
+}
+
+// SessionGetSignatureImageList - Obtain list of images stored in user account
+// Return
+//
+//	list
+func (c *ClientConnection) SessionGetSignatureImageList() (ImageList, error) {
+	return c.SessionGetSignatureImageListContext(context.Background())
+}
+
+// SessionGetSignatureImageListContext - the same as SessionGetSignatureImageList, but the call is bound to ctx.
+func (c *ClientConnection) SessionGetSignatureImageListContext(ctx context.Context) (ImageList, error) {
+	data, err := c.CallRawContext(ctx, "Session.getSignatureImageList", nil)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionGetSignatureImageListResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.List, err
+}
+
+// SessionAddSignatureImageResult - result of Session.addSignatureImage
+type SessionAddSignatureImageResult struct {
+	Errors ErrorList `json:"errors"` // list of errors
+	Result ImageList `json:"result"` // succesfuly added images
+}
+
+// SessionAddSignatureImage - Add image into user's store
+//
+//	ids - Upload IDs of images to add into user's store
+//
+// Return
+//
+//	errors - list of errors
+//	result - succesfuly added images
+func (c *ClientConnection) SessionAddSignatureImage(ids KIdList) (ErrorList, ImageList, error) {
+	return c.SessionAddSignatureImageContext(context.Background(), ids)
+}
+
+// SessionAddSignatureImageContext - the same as SessionAddSignatureImage, but the call is bound to ctx.
+func (c *ClientConnection) SessionAddSignatureImageContext(ctx context.Context, ids KIdList) (ErrorList, ImageList, error) {
+	params := struct {
+		Ids KIdList `json:"ids"`
+	}{ids}
+	data, err := c.CallRawContext(ctx, "Session.addSignatureImage", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := struct {
+		Result SessionAddSignatureImageResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, result.Result.Result, err
+}
+
+// SessionRemoveSignatureImageResult - result of Session.removeSignatureImage
+type SessionRemoveSignatureImageResult struct {
+	Errors ErrorList `json:"errors"`
+}
+
+// SessionRemoveSignatureImage - Remove image from user's store
+//
+//	ids - Image IDs to remove
+//
+// Return
+//
+//	errors
+func (c *ClientConnection) SessionRemoveSignatureImage(ids KIdList) (ErrorList, error) {
+	return c.SessionRemoveSignatureImageContext(context.Background(), ids)
+}
+
+// SessionRemoveSignatureImageContext - the same as SessionRemoveSignatureImage, but the call is bound to ctx.
+func (c *ClientConnection) SessionRemoveSignatureImageContext(ctx context.Context, ids KIdList) (ErrorList, error) {
+	params := struct {
+		Ids KIdList `json:"ids"`
+	}{ids}
+	data, err := c.CallRawContext(ctx, "Session.removeSignatureImage", params)
+	if err != nil {
+		return nil, err
+	}
+	result := struct {
+		Result SessionRemoveSignatureImageResult `json:"result"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	return result.Result.Errors, err
+}
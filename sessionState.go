@@ -0,0 +1,170 @@
+package webmail
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SessionCookie - cookie of the session
+type SessionCookie struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Path   string `json:"path,omitempty"`   // path the cookie is sent to, the API URL directory if empty
+	Domain string `json:"domain,omitempty"` // domain the cookie is sent to, only the host of the API if empty
+}
+
+// SessionState - serializable state of the logged in session, used to resume the session in another process
+type SessionState struct {
+	Server      string          `json:"server"`      // URL of the API
+	Token       string          `json:"token"`       // session token
+	Cookies     []SessionCookie `json:"cookies"`     // cookies of the server
+	Application ApiApplication  `json:"application"` // application used to log in
+}
+
+// ExportSession returns the state of the current session. Note that it contains the session token, keep it secret.
+func (c *ClientConnection) ExportSession() (*SessionState, error) {
+	token := c.token()
+	if token == nil {
+		return nil, errors.New("not logged in")
+	}
+	u, err := url.Parse(c.Config.url)
+	if err != nil {
+		return nil, err
+	}
+	state := SessionState{
+		Server: c.Config.url,
+		Token:  *token,
+	}
+	if app := c.application(); app != nil {
+		state.Application = *app
+	}
+	if c.client.Jar != nil {
+		for _, cookie := range c.client.Jar.Cookies(u) {
+			state.Cookies = append(state.Cookies, SessionCookie{
+				Name:   cookie.Name,
+				Value:  cookie.Value,
+				Path:   cookiePath(c.client.Jar, u, cookie),
+				Domain: cookieDomain(c.client.Jar, u, cookie),
+			})
+		}
+	}
+	return &state, nil
+}
+
+// ImportSession restores the session state exported by ExportSession, the session is not checked
+func (c *ClientConnection) ImportSession(state *SessionState) error {
+	if state.Server != c.Config.url {
+		return errors.New("session belongs to another server: " + state.Server)
+	}
+	u, err := url.Parse(c.Config.url)
+	if err != nil {
+		return err
+	}
+	if c.client.Jar != nil {
+		cookies := make([]*http.Cookie, len(state.Cookies))
+		for i, cookie := range state.Cookies {
+			cookies[i] = &http.Cookie{Name: cookie.Name, Value: cookie.Value, Path: cookie.Path, Domain: cookie.Domain}
+		}
+		c.client.Jar.SetCookies(u, cookies)
+	}
+	token := state.Token
+	app := state.Application
+	c.mu.Lock()
+	c.sessionToken = &token
+	c.app = &app
+	c.mu.Unlock()
+	return nil
+}
+
+// ResumeSession restores the session state and checks it by SessionWhoAmI.
+// If the state is nil or its session expired, it logs in again with credentials.
+//	state - session exported by ExportSession, can be nil
+//	credentials - source of user name and password for a new login, the error of the state is returned if nil
+//	app - application descriminator for a new login, the one from state is used if nil
+func (c *ClientConnection) ResumeSession(state *SessionState, credentials CredentialsFunc, app *ApiApplication) error {
+	return c.ResumeSessionContext(context.Background(), state, credentials, app)
+}
+
+// ResumeSessionContext - the same as ResumeSession, but the calls are bound to ctx.
+func (c *ClientConnection) ResumeSessionContext(ctx context.Context, state *SessionState, credentials CredentialsFunc, app *ApiApplication) error {
+	if state != nil {
+		err := c.ImportSession(state)
+		if err == nil {
+			_, err = c.SessionWhoAmIContext(ctx)
+			if err == nil {
+				return nil
+			}
+			if !errors.Is(err, ErrSessionExpired) && !errors.Is(err, ErrOperatorSessionExpired) {
+				return err
+			}
+		}
+		if credentials == nil {
+			return err
+		}
+	}
+	if credentials == nil {
+		return errors.New("no session state and no credentials")
+	}
+	if app == nil && state != nil && state.Application != (ApiApplication{}) {
+		app = &state.Application
+	}
+	creds, err := credentials(ctx)
+	if err != nil {
+		return err
+	}
+	return c.LoginContext(ctx, creds.UserName, creds.Password, app)
+}
+
+// cookiePath returns the shortest path of u where the jar sends the cookie, the jar does not keep the path itself
+func cookiePath(jar http.CookieJar, u *url.URL, cookie *http.Cookie) string {
+	path := ""
+	for _, segment := range strings.Split(strings.Trim(u.Path, "/"), "/") {
+		if hasCookie(jar, &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path + "/"}, cookie) {
+			break
+		}
+		path += "/" + segment
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// cookieDomain returns the widest parent domain of u where the jar sends the cookie,
+// or an empty string for the cookie of the host only
+func cookieDomain(jar http.CookieJar, u *url.URL, cookie *http.Cookie) string {
+	host := u.Hostname()
+	if net.ParseIP(host) != nil {
+		return ""
+	}
+	domain := ""
+	for i := strings.Index(host, "."); i >= 0; i = strings.Index(host, ".") {
+		if hasCookie(jar, &url.URL{Scheme: u.Scheme, Host: host, Path: u.Path}, cookie) {
+			domain = host
+		}
+		host = host[i+1:]
+	}
+	if domain == u.Hostname() && !hasCookie(jar, &url.URL{Scheme: u.Scheme, Host: "x." + domain, Path: u.Path}, cookie) {
+		return ""
+	}
+	return domain
+}
+
+func hasCookie(jar http.CookieJar, u *url.URL, cookie *http.Cookie) bool {
+	for _, c := range jar.Cookies(u) {
+		if c.Name == cookie.Name && c.Value == cookie.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClientConnection) application() *ApiApplication {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.app
+}
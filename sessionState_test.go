@@ -0,0 +1,125 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClientConnection_ResumeSession(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := parameters{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case req.Method == "Session.login":
+			logins++
+			http.SetCookie(w, &http.Cookie{Name: "SESSION", Value: "cookie"})
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"token":"token"}}`, req.ID)
+		case r.Header.Get("X-Token") == "token":
+			if cookie, err := r.Cookie("SESSION"); err != nil || cookie.Value != "cookie" {
+				t.Error("session cookie is not sent")
+			}
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"userDetails":{"loginName":"user"}}}`, req.ID)
+		default:
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32001,"message":"Session expired."}}`, req.ID)
+		}
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, err := conf.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := NewApplication("app", "vendor", "1")
+	if err = conn.Login("user", "password", app); err != nil {
+		t.Fatal(err)
+	}
+	state, err := conn.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := &SessionState{}
+	if err = json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Token != "token" || len(restored.Cookies) != 1 || restored.Application != *app {
+		t.Errorf("unexpected state %+v", restored)
+	}
+	credentials := StaticCredentials("user", "password")
+	conn, _ = conf.NewConnection()
+	if err = conn.ResumeSession(restored, credentials, nil); err != nil || logins != 1 {
+		t.Errorf("session is not resumed: %v, %d logins", err, logins)
+	}
+	restored.Token = "expired"
+	conn, _ = conf.NewConnection()
+	if err = conn.ResumeSession(restored, credentials, nil); err != nil || logins != 2 {
+		t.Errorf("expired session is not replaced: %v, %d logins", err, logins)
+	}
+	if conn.Token() != "token" {
+		t.Error("token is not updated")
+	}
+}
+
+func TestClientConnection_ExportSession_CookiePath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "SESSION", Value: "cookie", Path: "/webmail"})
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"token":"token"}}`)
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL + "/webmail/api/jsonrpc/"}
+	conn, _ := conf.NewConnection()
+	if err := conn.Login("user", "password", nil); err != nil {
+		t.Fatal(err)
+	}
+	state, err := conn.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Cookies) != 1 || state.Cookies[0].Path != "/webmail" || state.Cookies[0].Domain != "" {
+		t.Fatalf("unexpected cookies %+v", state.Cookies)
+	}
+	conn, _ = conf.NewConnection()
+	if err = conn.ImportSession(state); err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(server.URL + "/webmail/api/download/1/file")
+	if cookies := conn.client.Jar.Cookies(u); len(cookies) != 1 {
+		t.Errorf("cookie is not sent to %s", u)
+	}
+}
+
+func TestClientConnection_ResumeSession_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"Session expired."}}`)
+	}))
+	defer server.Close()
+	conf := &Config{url: server.URL}
+	conn, _ := conf.NewConnection()
+	state := &SessionState{Server: server.URL, Token: "expired"}
+	if err := conn.ResumeSession(state, nil, nil); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("expected ErrSessionExpired, got %v", err)
+	}
+	if err := conn.ResumeSession(nil, nil, nil); err == nil {
+		t.Error("resume without state and credentials must fail")
+	}
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":1000,"message":"Access denied"}}`)
+	})
+	credentials := func(ctx context.Context) (*Credentials, error) {
+		t.Error("credentials must not be used")
+		return nil, errors.New("no credentials")
+	}
+	if err := conn.ResumeSession(state, credentials, nil); err == nil {
+		t.Error("error of the session check is not returned")
+	}
+}
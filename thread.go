@@ -0,0 +1,322 @@
+package webmail
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Thread - conversation of mails built by ThreadMails
+type Thread struct {
+	Root           *ThreadNode // the first message of the conversation
+	Subject        string      // subject of the root without the prefixes of replies and forwards
+	Count          int         // number of mails in the thread
+	Unread         int         // number of mails which are not seen
+	LatestActivity time.Time   // the date of the newest mail
+	Latest         *Mail       // the newest mail
+	Participants   EMailList   // senders and recipients of the mails, unique by address, in the order of appearance
+}
+
+// ThreadNode - message of the thread with its replies
+type ThreadNode struct {
+	Mail     *Mail         // nil if the message is missing in the list and only its replies are there
+	Children []*ThreadNode // replies sorted by date
+}
+
+// MailsGetThreads reads the mails of the folders and groups them into threads by ThreadMails,
+// e.g. the folders of subtypes FSubInbox and FSubSentItems show both sides of the conversations.
+//	folderIds - list of global identifiers of folders to be listed
+//	query - query attributes, the fields must include the headers, the subject, the addresses, the dates and isSeen
+func (c *ClientConnection) MailsGetThreads(folderIds KIdList, query SearchQuery) ([]*Thread, error) {
+	return c.MailsGetThreadsContext(context.Background(), folderIds, query)
+}
+
+// MailsGetThreadsContext - the same as MailsGetThreads, but the calls are bound to ctx.
+func (c *ClientConnection) MailsGetThreadsContext(ctx context.Context, folderIds KIdList, query SearchQuery) ([]*Thread, error) {
+	var mails MailList
+	it := c.MailsGetIteratorContext(ctx, folderIds, query)
+	for it.Next() {
+		mails = append(mails, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return ThreadMails(mails), nil
+}
+
+// ThreadMails groups the mails into threads by their Message-ID and In-Reply-To headers using the algorithm of
+// Jamie Zawinski. The threads whose roots have the same subject without the prefixes of replies and forwards
+// are merged, so the mails without the headers are grouped by subject.
+// The threads are sorted by the latest activity, the newest first.
+func ThreadMails(mails MailList) []*Thread {
+	containers := map[string]*threadContainer{}
+	var all []*threadContainer // in order of creation to get the stable result
+	container := func(id string) *threadContainer {
+		c, ok := containers[id]
+		if !ok {
+			c = &threadContainer{}
+			containers[id] = c
+			all = append(all, c)
+		}
+		return c
+	}
+	for i := range mails {
+		mail := &mails[i]
+		var c *threadContainer
+		if ids := messageIds(messageID(*mail)); len(ids) > 0 {
+			c = container(ids[0])
+		}
+		if c == nil || c.mail != nil {
+			// no Message-ID or a duplicate, e.g. the mail sent to itself found in Inbox and Sent Items
+			c = &threadContainer{}
+			all = append(all, c)
+		}
+		c.mail = mail
+		var parent *threadContainer
+		for _, ref := range mailReferences(*mail) {
+			r := container(ref)
+			if parent != nil && r.parent == nil && r != parent && !parent.hasAncestor(r) {
+				r.setParent(parent)
+			}
+			parent = r
+		}
+		if parent != nil && (parent == c || parent.hasAncestor(c)) {
+			parent = nil
+		}
+		c.setParent(parent)
+	}
+	var roots []*threadContainer
+	for _, c := range all {
+		if c.parent == nil {
+			roots = append(roots, c)
+		}
+	}
+	roots = groupBySubject(pruneContainers(roots, true))
+	threads := make([]*Thread, 0, len(roots))
+	for _, c := range roots {
+		thread := &Thread{}
+		thread.Root = thread.add(c)
+		thread.Subject, _ = normalizeSubject(c.subject())
+		threads = append(threads, thread)
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].LatestActivity.After(threads[j].LatestActivity)
+	})
+	return threads
+}
+
+// add returns the node of the container and updates the summary of the thread
+func (t *Thread) add(c *threadContainer) *ThreadNode {
+	node := &ThreadNode{Mail: c.mail}
+	if mail := c.mail; mail != nil {
+		t.Count++
+		if !mail.IsSeen {
+			t.Unread++
+		}
+		if date := mailTime(*mail); t.Latest == nil || date.After(t.LatestActivity) {
+			t.Latest, t.LatestActivity = mail, date
+		}
+		for _, list := range []EMailList{{mail.From}, mail.To, mail.Cc} {
+			for _, email := range list {
+				t.addParticipant(email)
+			}
+		}
+	}
+	sort.SliceStable(c.children, func(i, j int) bool {
+		return c.children[i].date().Before(c.children[j].date())
+	})
+	for _, child := range c.children {
+		node.Children = append(node.Children, t.add(child))
+	}
+	return node
+}
+
+func (t *Thread) addParticipant(email EMail) {
+	if email.Address == "" {
+		return
+	}
+	for _, participant := range t.Participants {
+		if strings.EqualFold(participant.Address, email.Address) {
+			return
+		}
+	}
+	t.Participants = append(t.Participants, email)
+}
+
+// threadContainer - node of the tree built by ThreadMails
+type threadContainer struct {
+	mail     *Mail
+	parent   *threadContainer
+	children []*threadContainer
+}
+
+func (c *threadContainer) setParent(parent *threadContainer) {
+	if c.parent != nil {
+		siblings := c.parent.children
+		for i, sibling := range siblings {
+			if sibling == c {
+				c.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+	c.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, c)
+	}
+}
+
+func (c *threadContainer) hasAncestor(ancestor *threadContainer) bool {
+	for p := c.parent; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// subject returns the subject of the mail, or of its first reply if the mail is missing
+func (c *threadContainer) subject() string {
+	if c.mail != nil {
+		return c.mail.Subject
+	}
+	if len(c.children) > 0 {
+		return c.children[0].subject()
+	}
+	return ""
+}
+
+// date returns the date of the mail, or the earliest date of the replies if the mail is missing
+func (c *threadContainer) date() time.Time {
+	if c.mail != nil {
+		return mailTime(*c.mail)
+	}
+	var date time.Time
+	for _, child := range c.children {
+		if d := child.date(); date.IsZero() || d.Before(date) {
+			date = d
+		}
+	}
+	return date
+}
+
+// pruneContainers removes the containers without mails, their replies are moved to their parent.
+// The root without mail is kept if it has more replies.
+func pruneContainers(list []*threadContainer, root bool) []*threadContainer {
+	var result []*threadContainer
+	for _, c := range list {
+		c.children = pruneContainers(c.children, false)
+		if c.mail == nil && (len(c.children) == 0 || !root || len(c.children) == 1) {
+			for _, child := range c.children {
+				child.parent = c.parent
+			}
+			result = append(result, c.children...)
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// groupBySubject merges the roots with the same subject
+func groupBySubject(roots []*threadContainer) []*threadContainer {
+	subjects := map[string]*threadContainer{}
+	for _, c := range roots {
+		subject, reply := normalizeSubject(c.subject())
+		if subject == "" {
+			continue
+		}
+		key := strings.ToLower(subject)
+		old := subjects[key]
+		// prefer the root without mail and then the mail which is not a reply
+		if old == nil || (old.mail != nil && c.mail == nil) || (old.mail != nil && c.mail != nil && isReplySubject(old) && !reply) {
+			subjects[key] = c
+		}
+	}
+	var result []*threadContainer
+	for _, c := range roots {
+		subject, reply := normalizeSubject(c.subject())
+		target := subjects[strings.ToLower(subject)]
+		if subject == "" || target == nil || target == c {
+			result = append(result, c)
+			continue
+		}
+		switch {
+		case target.mail == nil && c.mail == nil:
+			for _, child := range append([]*threadContainer{}, c.children...) {
+				child.setParent(target)
+			}
+		case target.mail == nil || (!isReplySubject(target) && reply):
+			c.setParent(target)
+		default:
+			// both are the first mails, they become replies of a new root without mail
+			first := &threadContainer{mail: target.mail}
+			for _, child := range append([]*threadContainer{}, target.children...) {
+				child.setParent(first)
+			}
+			target.mail = nil
+			first.setParent(target)
+			c.setParent(target)
+		}
+	}
+	return result
+}
+
+func isReplySubject(c *threadContainer) bool {
+	_, reply := normalizeSubject(c.subject())
+	return reply
+}
+
+// subjectPrefix - prefix of a reply or a forward like "Re:", "Fwd:", "Re[2]:" or "AW:"
+var subjectPrefix = regexp.MustCompile(`(?i)^\s*(re|fwd?|aw|wg|sv|vs)\s*(\[\d+\]|\(\d+\))?\s*:\s*`)
+
+// normalizeSubject returns the subject without the prefixes of replies and forwards
+func normalizeSubject(subject string) (string, bool) {
+	prefixed := false
+	for {
+		loc := subjectPrefix.FindStringIndex(subject)
+		if loc == nil {
+			return strings.TrimSpace(subject), prefixed
+		}
+		subject = subject[loc[1]:]
+		prefixed = true
+	}
+}
+
+// messageIdPattern - message identifier in Message-ID or In-Reply-To header
+var messageIdPattern = regexp.MustCompile(`<[^<>\s]+>`)
+
+// messageIds returns the message identifiers of the header value, the value without angle brackets is one identifier
+func messageIds(value string) []string {
+	ids := messageIdPattern.FindAllString(value, -1)
+	if len(ids) == 0 && strings.TrimSpace(value) != "" {
+		ids = []string{"<" + strings.TrimSpace(value) + ">"}
+	}
+	return ids
+}
+
+// mailReferences returns the message identifiers of In-Reply-To headers of the mail
+func mailReferences(mail Mail) []string {
+	var refs []string
+	for _, header := range mail.Headers {
+		if header.Type == MhInReplayTo {
+			refs = append(refs, messageIds(header.Value)...)
+		}
+	}
+	return refs
+}
+
+// mailTime returns the date when the mail was received, or sent if it is not known
+func mailTime(mail Mail) time.Time {
+	date := mail.ReceiveDate
+	if date.IsZero() {
+		date = mail.SendDate
+	}
+	t, err := date.Time()
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
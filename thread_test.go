@@ -0,0 +1,87 @@
+package webmail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThreadMails(t *testing.T) {
+	day := func(d int) UtcDateTime {
+		return UtcDateTimeFromTime(time.Date(2020, 3, d, 12, 0, 0, 0, time.UTC))
+	}
+	mail := func(id, subject, from string, date int, seen bool, messageId, inReplyTo string) Mail {
+		m := Mail{Id: KId(id), Subject: subject, From: EMail{Address: from}, ReceiveDate: day(date), IsSeen: seen}
+		if messageId != "" {
+			m.Headers = append(m.Headers, MimeHeader{Type: MhMessageID, Value: messageId})
+		}
+		if inReplyTo != "" {
+			m.Headers = append(m.Headers, MimeHeader{Type: MhInReplayTo, Value: inReplyTo})
+		}
+		return m
+	}
+	mails := MailList{
+		mail("1", "Printer broken", "alice@example.com", 1, true, "<1@example.com>", ""),
+		mail("2", "Re: Printer broken", "desk@example.com", 2, true, "<2@example.com>", "<1@example.com>"),
+		mail("3", "RE: Printer broken", "Alice@example.com", 4, false, "<3@example.com>", "<2@example.com>"),
+		// reply to the message which is not in the list
+		mail("4", "Re: VPN", "bob@example.com", 3, false, "<4@example.com>", "<missing@example.com>"),
+		mail("5", "Re: VPN", "desk@example.com", 5, true, "<5@example.com>", "<missing@example.com>"),
+		// no headers, grouped by subject
+		mail("6", "Lunch", "carol@example.com", 1, true, "", ""),
+		mail("7", "Re[2]: lunch", "dave@example.com", 2, false, "", ""),
+		// loop of references is ignored
+		mail("8", "Loop", "eve@example.com", 1, true, "<8@example.com>", "<9@example.com>"),
+		mail("9", "Re: Loop", "eve@example.com", 2, true, "<9@example.com>", "<8@example.com>"),
+	}
+	mails[0].To = EMailList{{Address: "desk@example.com"}}
+	threads := ThreadMails(mails)
+	if len(threads) != 4 {
+		t.Fatalf("got %d threads", len(threads))
+	}
+
+	vpn := threads[0]
+	if vpn.Subject != "VPN" || vpn.Root.Mail != nil || len(vpn.Root.Children) != 2 || vpn.Count != 2 || vpn.Unread != 1 {
+		t.Errorf("VPN thread %+v", vpn)
+	}
+
+	printer := threads[1]
+	if printer.Subject != "Printer broken" || printer.Count != 3 || printer.Unread != 1 || printer.Latest.Id != "3" ||
+		!printer.LatestActivity.Equal(time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("printer thread %+v", printer)
+	}
+	if root := printer.Root; root.Mail.Id != "1" || len(root.Children) != 1 || root.Children[0].Mail.Id != "2" ||
+		len(root.Children[0].Children) != 1 || root.Children[0].Children[0].Mail.Id != "3" {
+		t.Errorf("printer tree %+v", root)
+	}
+	if len(printer.Participants) != 2 || printer.Participants[0].Address != "alice@example.com" ||
+		printer.Participants[1].Address != "desk@example.com" {
+		t.Errorf("participants %+v", printer.Participants)
+	}
+
+	lunch := threads[2]
+	if lunch.Subject != "Lunch" || lunch.Root.Mail.Id != "6" || len(lunch.Root.Children) != 1 || lunch.Root.Children[0].Mail.Id != "7" {
+		t.Errorf("lunch thread %+v", lunch.Root)
+	}
+	if loop := threads[3]; loop.Count != 2 || loop.Subject != "Loop" {
+		t.Errorf("loop thread %+v", loop)
+	}
+}
+
+func TestNormalizeSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+		reply   bool
+	}{
+		{"Report", "Report", false},
+		{"Re: Report", "Report", true},
+		{"Fwd: RE: Report ", "Report", true},
+		{"AW:Re(3): Report", "Report", true},
+		{"Reports: Q3", "Reports: Q3", false},
+	}
+	for _, test := range tests {
+		if got, reply := normalizeSubject(test.subject); got != test.want || reply != test.reply {
+			t.Errorf("%q: got %q, %v", test.subject, got, reply)
+		}
+	}
+}
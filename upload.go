@@ -0,0 +1,190 @@
+package webmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// UploadMethod - method of CallRecord of an upload
+const UploadMethod = "upload"
+
+const (
+	uploadPath  = "/upload/"  // upload endpoint relative to the URL of JSON-RPC
+	uploadField = "newFile"   // form field with the uploaded file
+	octetStream = "application/octet-stream"
+)
+
+// Upload sends the file to the server and returns its ID, which is used as Attachment.Id, PhotoAttachment.Id,
+// fileId of CertificatesImportPKCS12 or ids of SessionAddSignatureImage.
+// The file is streamed from r, so the upload is retried after auto re-login only if r is io.Seeker.
+// The server rejects files bigger than its limit with an error matching ErrRequestEntityTooLarge.
+//	r - content of the file
+//	name - filename
+//	contentType - MIME type, "application/octet-stream" if empty
+func (c *ClientConnection) Upload(r io.Reader, name, contentType string) (string, error) {
+	return c.UploadContext(context.Background(), r, name, contentType)
+}
+
+// UploadContext - the same as Upload, but the request is bound to ctx.
+func (c *ClientConnection) UploadContext(ctx context.Context, r io.Reader, name, contentType string) (string, error) {
+	id, _, err := c.uploadContext(ctx, r, name, contentType)
+	return id, err
+}
+
+// uploadContext uploads the file with re-login and returns its ID and size
+func (c *ClientConnection) uploadContext(ctx context.Context, r io.Reader, name, contentType string) (string, int64, error) {
+	seeker, seekable := r.(io.Seeker)
+	var offset int64
+	if seekable {
+		var err error
+		if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
+	}
+	token := c.token()
+	id, size, err := c.upload(ctx, r, name, contentType, token)
+	if err != nil && c.needRelogin(UploadMethod, err) {
+		if err := c.reloginContext(ctx, token); err != nil {
+			return "", 0, err
+		}
+		if !seekable {
+			return "", 0, err
+		}
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return "", 0, err
+		}
+		id, size, err = c.upload(ctx, r, name, contentType, c.token())
+	}
+	return id, size, err
+}
+
+// upload performs a single upload and returns the ID and the size of the file
+func (c *ClientConnection) upload(ctx context.Context, r io.Reader, name, contentType string, token *string) (string, int64, error) {
+	if c.Config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.timeout)
+		defer cancel()
+	}
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	sizes := make(chan int64, 1)
+	go func() {
+		size, err := writeUploadForm(form, r, name, contentType)
+		_ = writer.CloseWithError(err)
+		sizes <- size
+	}()
+	start := time.Now()
+	id, data, err := c.postUpload(ctx, body, form.FormDataContentType(), name, token)
+	// stops writing of the form if the server has not read it all
+	_ = body.Close()
+	size := <-sizes
+	c.logCall(ctx, CallRecord{
+		Method:       UploadMethod,
+		Duration:     time.Since(start),
+		RequestSize:  int(size),
+		ResponseSize: len(data),
+		Err:          err,
+	}, token, nil)
+	return id, size, err
+}
+
+// postUpload sends the form and returns the ID of the file and the response body
+func (c *ClientConnection) postUpload(ctx context.Context, body io.Reader, formType, name string, token *string) (string, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Config.url+uploadPath, body)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header = newRequestHeader(ctx, token)
+	req.Header.Set("Content-Type", formType)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return "", nil, &ApiError{Code: ErrorCodeRequestEntityTooLarge, Message: ErrRequestEntityTooLarge.Message, PositionalParameters: []string{name}}
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", data, err
+	}
+	if err = checkResponse(resp, data); err != nil {
+		return "", data, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", data, fmt.Errorf("upload of %s: %s", name, resp.Status)
+	}
+	upload := struct {
+		Result struct {
+			Id string `json:"id"`
+		} `json:"result"`
+	}{}
+	if err = json.Unmarshal(data, &upload); err != nil {
+		return "", data, err
+	}
+	if upload.Result.Id == "" {
+		return "", data, errors.New("upload of " + name + ": no id in response")
+	}
+	return upload.Result.Id, data, nil
+}
+
+// checkResponse returns the error reported in the response body, the status 401 Unauthorized means the expired session
+func checkResponse(resp *http.Response, data []byte) error {
+	if err := checkError(data); err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &ApiError{Code: ErrorCodeSessionExpired, Message: ErrSessionExpired.Message}
+	}
+	return nil
+}
+
+// UploadAttachment uploads the file and returns the attachment to be added to Mail.Attachments
+//	r - content of the file
+//	name - filename
+//	contentType - MIME type, "application/octet-stream" if empty
+func (c *ClientConnection) UploadAttachment(r io.Reader, name, contentType string) (*Attachment, error) {
+	return c.UploadAttachmentContext(context.Background(), r, name, contentType)
+}
+
+// UploadAttachmentContext - the same as UploadAttachment, but the request is bound to ctx.
+func (c *ClientConnection) UploadAttachmentContext(ctx context.Context, r io.Reader, name, contentType string) (*Attachment, error) {
+	id, size, err := c.uploadContext(ctx, r, name, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		contentType = octetStream
+	}
+	return &Attachment{Id: id, Name: name, ContentType: contentType, Size: int(size)}, nil
+}
+
+// writeUploadForm writes the multipart form with the file and returns the size of the file
+func writeUploadForm(form *multipart.Writer, r io.Reader, name, contentType string) (int64, error) {
+	if contentType == "" {
+		contentType = octetStream
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, uploadField, quoteEscaper.Replace(name)))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(part, r)
+	if err != nil {
+		return size, err
+	}
+	return size, form.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,122 @@
+package webmailtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/igiant/webmail"
+)
+
+const downloadPath = "/webmail/api/download/"
+
+// File - file uploaded to the server or added for download
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// SetUploadLimit sets the maximum size of uploaded file in bytes, bigger files are rejected
+// with ErrorCodeRequestEntityTooLarge. Zero means no limit.
+func (s *Server) SetUploadLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploadLimit = limit
+}
+
+// Upload returns the uploaded file with the id
+func (s *Server) Upload(id string) (File, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, ok := s.uploads[id]
+	return file, ok
+}
+
+// serveUpload receives the file sent as multipart form like the upload endpoint of the server
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.sessions[r.Header.Get("X-Token")]
+	limit := s.uploadLimit
+	s.mu.Unlock()
+	if !ok {
+		writeResponse(w, http.StatusOK, errorResponse(0, webmail.ErrSessionExpired))
+		return
+	}
+	part, header, err := r.FormFile("newFile")
+	if err != nil {
+		writeResponse(w, http.StatusOK, errorResponse(0, invalidParams(err)))
+		return
+	}
+	defer func() { _ = part.Close() }()
+	if limit > 0 && header.Size > int64(limit) {
+		writeResponse(w, http.StatusRequestEntityTooLarge, errorResponse(0, webmail.ErrRequestEntityTooLarge))
+		return
+	}
+	data, err := ioutil.ReadAll(part)
+	if err != nil {
+		writeResponse(w, http.StatusOK, errorResponse(0, err))
+		return
+	}
+	s.mu.Lock()
+	id := string(s.newID())
+	s.uploads[id] = File{Name: header.Filename, ContentType: header.Header.Get("Content-Type"), Data: data}
+	s.mu.Unlock()
+	result := struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}{id, header.Filename, len(data)}
+	writeResponse(w, http.StatusOK, response{JsonRpc: "2.0", Result: result})
+}
+
+// AddFile adds the file which can be downloaded, its URL is relative to the root of web
+// like Attachment.Url or Download.Url sent by the server
+func (s *Server) AddFile(name, contentType string, data []byte) webmail.Download {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.addDownload(File{Name: name, ContentType: contentType, Data: data})
+	return webmail.Download{Url: u, Name: name, Length: len(data)}
+}
+
+// addDownload returns the URL of the new downloadable file, the caller must hold s.mu
+func (s *Server) addDownload(file File) string {
+	u := downloadPath + string(s.newID()) + "/" + url.PathEscape(file.Name)
+	s.downloads[u] = file
+	return u
+}
+
+// attachUploads makes the uploaded attachments of the mail downloadable, the caller must hold s.mu
+func (s *Server) attachUploads(mail object) {
+	attachments, _ := mail["attachments"].([]interface{})
+	for _, value := range attachments {
+		attachment, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := attachment["id"].(string)
+		file, found := s.uploads[id]
+		if !found {
+			continue
+		}
+		attachment["url"] = s.addDownload(file)
+		attachment["size"] = float64(len(file.Data))
+	}
+}
+
+// serveDownload sends the file added by AddFile to the logged in user
+func (s *Server) serveDownload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.sessions[r.Header.Get("X-Token")]
+	file, found := s.downloads[r.URL.EscapedPath()]
+	s.mu.Unlock()
+	switch {
+	case !ok:
+		writeResponse(w, http.StatusUnauthorized, errorResponse(0, webmail.ErrSessionExpired))
+	case !found:
+		http.NotFound(w, r)
+	default:
+		w.Header().Set("Content-Type", file.ContentType)
+		_, _ = w.Write(file.Data)
+	}
+}
@@ -0,0 +1,518 @@
+package webmailtest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/igiant/webmail"
+)
+
+// Identifiers of the default folders of a new server
+const (
+	RootFolderId         webmail.KId = "root"
+	InboxFolderId        webmail.KId = "inbox"
+	DraftsFolderId       webmail.KId = "drafts"
+	SentItemsFolderId    webmail.KId = "sent"
+	DeletedItemsFolderId webmail.KId = "deleted"
+	JunkEmailFolderId    webmail.KId = "junk"
+	ContactsFolderId     webmail.KId = "contacts"
+	CalendarFolderId     webmail.KId = "calendar"
+	TasksFolderId        webmail.KId = "tasks"
+	NotesFolderId        webmail.KId = "notes"
+)
+
+func (s *Server) addDefaultFolders() {
+	folders := []webmail.Folder{
+		{Id: RootFolderId, Name: "", Type: webmail.FRoot},
+		{Id: InboxFolderId, ParentId: RootFolderId, Name: "Inbox", Type: webmail.FMail, SubType: webmail.FSubInbox},
+		{Id: DraftsFolderId, ParentId: RootFolderId, Name: "Drafts", Type: webmail.FMail, SubType: webmail.FSubDrafts},
+		{Id: SentItemsFolderId, ParentId: RootFolderId, Name: "Sent Items", Type: webmail.FMail, SubType: webmail.FSubSentItems},
+		{Id: DeletedItemsFolderId, ParentId: RootFolderId, Name: "Deleted Items", Type: webmail.FMail, SubType: webmail.FSubDeletedItems},
+		{Id: JunkEmailFolderId, ParentId: RootFolderId, Name: "Junk E-mail", Type: webmail.FMail, SubType: webmail.FSubJunkEmail},
+		{Id: ContactsFolderId, ParentId: RootFolderId, Name: "Contacts", Type: webmail.FContact, SubType: webmail.FSubDefault},
+		{Id: CalendarFolderId, ParentId: RootFolderId, Name: "Calendar", Type: webmail.FCalendar, SubType: webmail.FSubDefault},
+		{Id: TasksFolderId, ParentId: RootFolderId, Name: "Tasks", Type: webmail.FTask, SubType: webmail.FSubDefault},
+		{Id: NotesFolderId, ParentId: RootFolderId, Name: "Notes", Type: webmail.FNote, SubType: webmail.FSubDefault},
+	}
+	for _, folder := range folders {
+		folder.PlaceType = webmail.FPlaceMailbox
+		folder.Access = webmail.FAccessAdmin
+		if folder.Type != webmail.FRoot {
+			folder.NestingLevel = 1
+		}
+		o, _ := toObject(folder)
+		s.collections["Folders"].items = append(s.collections["Folders"].items, o)
+	}
+}
+
+// add stores the item into the collection and returns its identifier
+func (s *Server) add(name string, item interface{}) webmail.KId {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := toObject(item)
+	if err != nil {
+		panic(err)
+	}
+	if o.id() == "" {
+		o["id"] = string(s.newID())
+	}
+	o["watermark"] = float64(s.nextWatermark())
+	s.collections[name].items = append(s.collections[name].items, o)
+	return o.id()
+}
+
+// list returns the items of the collection in folder, all items if folderId is empty
+func (s *Server) list(name string, folderId webmail.KId, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var folderIds webmail.KIdList
+	if folderId != "" {
+		folderIds = webmail.KIdList{folderId}
+	}
+	items := s.collections[name].inFolders(folderIds)
+	list := make([]object, len(items))
+	for i, item := range items {
+		list[i] = item.clone()
+	}
+	fromObject(object{"list": list}, v)
+}
+
+// AddFolder adds the folder and returns its identifier. An empty Id is generated.
+func (s *Server) AddFolder(folder webmail.Folder) webmail.KId {
+	if folder.ParentId == "" {
+		folder.ParentId = RootFolderId
+	}
+	return s.add("Folders", folder)
+}
+
+// AddMail adds the mail and returns its identifier. An empty Id is generated, FolderId is required.
+func (s *Server) AddMail(mail webmail.Mail) webmail.KId {
+	return s.add("Mails", mail)
+}
+
+// AddContact adds the contact and returns its identifier. An empty Id is generated.
+func (s *Server) AddContact(contact webmail.Contact) webmail.KId {
+	return s.add("Contacts", contact)
+}
+
+// AddEvent adds the event and returns its identifier. An empty Id is generated.
+func (s *Server) AddEvent(event webmail.Event) webmail.KId {
+	return s.add("Events", event)
+}
+
+// AddTask adds the task and returns its identifier. An empty Id is generated.
+func (s *Server) AddTask(task webmail.Task) webmail.KId {
+	return s.add("Tasks", task)
+}
+
+// AddNote adds the note and returns its identifier. An empty Id is generated.
+func (s *Server) AddNote(note webmail.Note) webmail.KId {
+	return s.add("Notes", note)
+}
+
+// Folders returns all folders
+func (s *Server) Folders() webmail.FolderList {
+	list := struct{ List webmail.FolderList }{}
+	s.list("Folders", "", &list)
+	return list.List
+}
+
+// Mails returns the mails in the folder, all mails if folderId is empty
+func (s *Server) Mails(folderId webmail.KId) webmail.MailList {
+	list := struct{ List webmail.MailList }{}
+	s.list("Mails", folderId, &list)
+	return list.List
+}
+
+// Contacts returns the contacts in the folder, all contacts if folderId is empty
+func (s *Server) Contacts(folderId webmail.KId) webmail.ContactList {
+	list := struct{ List webmail.ContactList }{}
+	s.list("Contacts", folderId, &list)
+	return list.List
+}
+
+// Events returns the events in the folder, all events if folderId is empty
+func (s *Server) Events(folderId webmail.KId) webmail.EventList {
+	list := struct{ List webmail.EventList }{}
+	s.list("Events", folderId, &list)
+	return list.List
+}
+
+// Tasks returns the tasks in the folder, all tasks if folderId is empty
+func (s *Server) Tasks(folderId webmail.KId) webmail.TaskList {
+	list := struct{ List webmail.TaskList }{}
+	s.list("Tasks", folderId, &list)
+	return list.List
+}
+
+// Notes returns the notes in the folder, all notes if folderId is empty
+func (s *Server) Notes(folderId webmail.KId) webmail.NoteList {
+	list := struct{ List webmail.NoteList }{}
+	s.list("Notes", folderId, &list)
+	return list.List
+}
+
+// Filters returns the filtering rules
+func (s *Server) Filters() webmail.FilterRuleList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, _ := json.Marshal(s.filters)
+	var filters webmail.FilterRuleList
+	_ = json.Unmarshal(data, &filters)
+	return filters
+}
+
+func (s *Server) registerHandlers() {
+	s.handlers["Session.login"] = s.login
+	s.handlers["Session.logout"] = s.logout
+	s.handlers["Session.whoAmI"] = s.whoAmI
+	s.handlers["Session.getQuotaInformation"] = s.getQuotaInformation
+	s.handlers["Folders.get"] = s.getFolders
+	s.handlers["Folders.create"] = s.createItems("Folders", "folders", false)
+	s.handlers["Folders.set"] = s.setItems("Folders", "folders")
+	s.handlers["Folders.remove"] = s.removeItems("Folders")
+	s.handlers["Mails.setAllSeen"] = s.setAllSeen
+	s.handlers["Filters.get"] = s.getFilters
+	s.handlers["Filters.set"] = s.setFilters
+	for name, param := range map[string]string{
+		"Mails":    "mails",
+		"Contacts": "contacts",
+		"Events":   "events",
+		"Tasks":    "tasks",
+		"Notes":    "notes",
+	} {
+		folders := "folderIds"
+		if name == "Events" {
+			folders = "ids"
+		}
+		s.handlers[name+".get"] = s.getItems(name, folders)
+		s.handlers[name+".create"] = s.createItems(name, param, name == "Mails")
+		s.handlers[name+".set"] = s.setItems(name, param)
+		s.handlers[name+".remove"] = s.removeItems(name)
+		s.handlers[name+".copy"] = s.copyItems(name, false)
+		s.handlers[name+".move"] = s.copyItems(name, true)
+		if name == "Events" {
+			s.handlers[name+".getById"] = s.getEventById
+		} else {
+			s.handlers[name+".getById"] = s.getItemsById(name)
+		}
+	}
+}
+
+func (s *Server) login(_ string, params json.RawMessage) (interface{}, error) {
+	credentials := webmail.Credentials{}
+	if err := json.Unmarshal(params, &credentials); err != nil {
+		return nil, invalidParams(err)
+	}
+	password, ok := s.users[credentials.UserName]
+	if !ok || password != credentials.Password {
+		return nil, &webmail.ApiError{Code: webmail.ErrorCodeAccessDenied, Message: "Invalid user name or password"}
+	}
+	token := "token-" + string(s.newID())
+	s.sessions[token] = credentials.UserName
+	return map[string]string{"token": token}, nil
+}
+
+func (s *Server) logout(userName string, _ json.RawMessage) (interface{}, error) {
+	for token, user := range s.sessions {
+		if user == userName {
+			delete(s.sessions, token)
+		}
+	}
+	return struct{}{}, nil
+}
+
+func (s *Server) whoAmI(userName string, _ json.RawMessage) (interface{}, error) {
+	address := userName
+	if !strings.Contains(address, "@") {
+		address += "@example.com"
+	}
+	return map[string]webmail.UserInfo{"userDetails": {
+		Id:               webmail.KId(userName),
+		LoginName:        address,
+		FullName:         userName,
+		Emails:           webmail.StringList{address},
+		PreferredAddress: address,
+	}}, nil
+}
+
+func (s *Server) getQuotaInformation(_ string, _ json.RawMessage) (interface{}, error) {
+	return map[string]webmail.QuotaInfo{"quotaInfo": {
+		MessagesLimit: s.quota,
+		MessagesUsed:  len(s.collections["Mails"].items),
+	}}, nil
+}
+
+// getFolders returns folders with the counts of their mails
+func (s *Server) getFolders(_ string, _ json.RawMessage) (interface{}, error) {
+	folders := s.collections["Folders"].items
+	list := make([]object, len(folders))
+	for i, folder := range folders {
+		o := folder.clone()
+		count, unread := 0, 0
+		for _, mail := range s.collections["Mails"].inFolders(webmail.KIdList{folder.id()}) {
+			count++
+			if seen, _ := mail["isSeen"].(bool); !seen {
+				unread++
+			}
+		}
+		o["messageCount"] = float64(count)
+		o["messageUnread"] = float64(unread)
+		list[i] = o
+	}
+	return map[string]interface{}{"list": list}, nil
+}
+
+func (s *Server) getItems(name, folders string) HandlerFunc {
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := map[string]json.RawMessage{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		var folderIds webmail.KIdList
+		query := webmail.SearchQuery{Limit: -1}
+		if err := unmarshalMember(p, folders, &folderIds); err != nil {
+			return nil, err
+		}
+		if err := unmarshalMember(p, "query", &query); err != nil {
+			return nil, err
+		}
+		for _, id := range folderIds {
+			if s.collections["Folders"].find(id) == nil {
+				return nil, &webmail.ApiError{Code: webmail.ErrorCodeNoSuchFolder, Message: "Folder doesn't exist", PositionalParameters: []string{string(id)}}
+			}
+		}
+		list, total := search(s.collections[name].inFolders(folderIds), query)
+		if list == nil {
+			list = []object{}
+		}
+		return map[string]interface{}{"list": list, "totalItems": total}, nil
+	}
+}
+
+func (s *Server) getItemsById(name string) HandlerFunc {
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := struct {
+			Ids webmail.KIdList `json:"ids"`
+		}{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		faults := s.takeItemFaults(name + ".getById")
+		errors, result := webmail.ErrorList{}, []object{}
+		for i, id := range p.Ids {
+			if err, ok := faults[i]; ok {
+				errors = append(errors, itemError(i, err))
+			} else if item := s.collections[name].find(id); item == nil {
+				errors = append(errors, itemError(i, webmail.ErrNoSuchEntity))
+			} else {
+				result = append(result, item.clone())
+			}
+		}
+		return map[string]interface{}{"errors": errors, "result": result}, nil
+	}
+}
+
+func (s *Server) getEventById(_ string, params json.RawMessage) (interface{}, error) {
+	p := struct {
+		Id webmail.KId `json:"id"`
+	}{}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+	item := s.collections["Events"].find(p.Id)
+	if item == nil {
+		return nil, webmail.ErrNoSuchEntity
+	}
+	return map[string]interface{}{"result": item.clone()}, nil
+}
+
+// createItems stores new items; sent mails are stored in Sent Items
+func (s *Server) createItems(name, param string, isMail bool) HandlerFunc {
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := map[string][]object{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		faults := s.takeItemFaults(name + ".create")
+		errors, result := webmail.ErrorList{}, webmail.CreateResultList{}
+		for i, item := range p[param] {
+			if err, ok := faults[i]; ok {
+				errors = append(errors, itemError(i, err))
+				continue
+			}
+			if isMail {
+				if s.quota > 0 && len(s.collections[name].items) >= s.quota {
+					errors = append(errors, itemError(i, webmail.ErrQuotaReached))
+					continue
+				}
+				if send, _ := item["send"].(bool); send {
+					item["folderId"] = string(SentItemsFolderId)
+					item["isSeen"] = true
+					item["isDraft"] = false
+				} else if item.folderId() == "" {
+					item["folderId"] = string(DraftsFolderId)
+					item["isDraft"] = true
+				}
+				delete(item, "send")
+				s.attachUploads(item)
+			}
+			if name == "Folders" && item["parentId"] == "" {
+				item["parentId"] = string(RootFolderId)
+			}
+			if name != "Folders" && s.collections["Folders"].find(item.folderId()) == nil {
+				errors = append(errors, itemError(i, webmail.ErrNoSuchFolder))
+				continue
+			}
+			item["id"] = string(s.newID())
+			watermark := s.nextWatermark()
+			item["watermark"] = float64(watermark)
+			s.collections[name].items = append(s.collections[name].items, item)
+			result = append(result, webmail.CreateResult{InputIndex: i, Id: item.id(), Watermark: watermark})
+		}
+		return map[string]interface{}{"errors": errors, "result": result}, nil
+	}
+}
+
+func (s *Server) setItems(name, param string) HandlerFunc {
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := map[string][]object{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		faults := s.takeItemFaults(name + ".set")
+		errors, result := webmail.ErrorList{}, webmail.SetResultList{}
+		for i, item := range p[param] {
+			if err, ok := faults[i]; ok {
+				errors = append(errors, itemError(i, err))
+				continue
+			}
+			stored := s.collections[name].find(item.id())
+			if stored == nil {
+				errors = append(errors, itemError(i, webmail.ErrNoSuchEntity))
+				continue
+			}
+			delete(item, "watermark")
+			stored.merge(item)
+			watermark := s.nextWatermark()
+			stored["watermark"] = float64(watermark)
+			result = append(result, webmail.SetResult{InputIndex: i, Watermark: watermark})
+		}
+		return map[string]interface{}{"errors": errors, "result": result}, nil
+	}
+}
+
+func (s *Server) removeItems(name string) HandlerFunc {
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := struct {
+			Ids webmail.KIdList `json:"ids"`
+		}{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		faults := s.takeItemFaults(name + ".remove")
+		errors := webmail.ErrorList{}
+		for i, id := range p.Ids {
+			if err, ok := faults[i]; ok {
+				errors = append(errors, itemError(i, err))
+			} else if !s.collections[name].remove(id) {
+				errors = append(errors, itemError(i, webmail.ErrNoSuchEntity))
+			}
+		}
+		return map[string]interface{}{"errors": errors}, nil
+	}
+}
+
+func (s *Server) copyItems(name string, move bool) HandlerFunc {
+	method := name + ".copy"
+	if move {
+		method = name + ".move"
+	}
+	return func(_ string, params json.RawMessage) (interface{}, error) {
+		p := struct {
+			Ids    webmail.KIdList `json:"ids"`
+			Folder webmail.KId     `json:"folder"`
+		}{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		if s.collections["Folders"].find(p.Folder) == nil {
+			return nil, webmail.ErrNoSuchFolder
+		}
+		faults := s.takeItemFaults(method)
+		errors, result := webmail.ErrorList{}, webmail.CreateResultList{}
+		for i, id := range p.Ids {
+			if err, ok := faults[i]; ok {
+				errors = append(errors, itemError(i, err))
+				continue
+			}
+			item := s.collections[name].find(id)
+			if item == nil {
+				errors = append(errors, itemError(i, webmail.ErrNoSuchEntity))
+				continue
+			}
+			if !move {
+				item = item.clone()
+				item["id"] = string(s.newID())
+				s.collections[name].items = append(s.collections[name].items, item)
+			}
+			item["folderId"] = string(p.Folder)
+			watermark := s.nextWatermark()
+			item["watermark"] = float64(watermark)
+			result = append(result, webmail.CreateResult{InputIndex: i, Id: item.id(), Watermark: watermark})
+		}
+		return map[string]interface{}{"errors": errors, "result": result}, nil
+	}
+}
+
+func (s *Server) setAllSeen(_ string, params json.RawMessage) (interface{}, error) {
+	p := struct {
+		FolderId webmail.KId `json:"folderId"`
+	}{}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+	if s.collections["Folders"].find(p.FolderId) == nil {
+		return nil, webmail.ErrNoSuchFolder
+	}
+	for _, mail := range s.collections["Mails"].inFolders(webmail.KIdList{p.FolderId}) {
+		mail["isSeen"] = true
+	}
+	return struct{}{}, nil
+}
+
+func (s *Server) getFilters(_ string, _ json.RawMessage) (interface{}, error) {
+	filters := s.filters
+	if filters == nil {
+		filters = []json.RawMessage{}
+	}
+	return map[string]interface{}{"dataStamp": s.dataStamp, "filters": filters}, nil
+}
+
+func (s *Server) setFilters(_ string, params json.RawMessage) (interface{}, error) {
+	p := struct {
+		CurrentDataStamp uint64            `json:"currentDataStamp"`
+		Filters          []json.RawMessage `json:"filters"`
+	}{}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams(err)
+	}
+	if p.CurrentDataStamp != s.dataStamp {
+		return nil, &webmail.ApiError{Code: webmail.ErrorCodeOperationFailed, Message: "Filters were modified"}
+	}
+	s.filters = p.Filters
+	s.dataStamp++
+	return map[string]uint64{"newDataStamp": s.dataStamp}, nil
+}
+
+// unmarshalMember decodes the member of params into v if present
+func unmarshalMember(params map[string]json.RawMessage, name string, v interface{}) error {
+	data, ok := params[name]
+	if !ok || string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return invalidParams(err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// Package webmailtest provides an in-memory fake of the Kerio Connect client API server
+// for testing code which uses webmail.ClientConnection without a real server.
+package webmailtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/igiant/webmail"
+)
+
+const (
+	DefaultUser     = "user"     // user name accepted by a new server
+	DefaultPassword = "password" // password of DefaultUser
+
+	apiPath = "/webmail/api/jsonrpc"
+)
+
+// HandlerFunc serves one API method.
+//	userName - user of the session
+//	params - "params" member of the request
+// Return
+//	result - value of the "result" member of the response
+//	err - error reported in the "error" member, *webmail.ApiError is sent with its code
+type HandlerFunc func(userName string, params json.RawMessage) (result interface{}, err error)
+
+// Server - fake API server listening on a local address. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+	mu          sync.Mutex
+	users       map[string]string // user name -> password
+	sessions    map[string]string // token -> user name
+	handlers    map[string]HandlerFunc
+	custom      map[string]bool          // methods served by the handlers set by Handle
+	faults      map[string][]error       // method -> errors of next calls, "" for any method
+	itemFaults  map[string]map[int]error // method -> errors of input items of the next call
+	collections map[string]*collection   // interface name -> items
+	calls       map[string]int
+	lastID      int
+	watermark   webmail.Watermark
+	dataStamp   uint64
+	filters     []json.RawMessage
+	quota       int // limit of mails, 0 means no limit
+	uploads     map[string]File
+	downloads   map[string]File // URL path -> file
+	uploadLimit int             // limit of uploaded file size, 0 means no limit
+}
+
+// NewServer starts a new server with DefaultUser and the default folders
+func NewServer() *Server {
+	s := &Server{
+		users:       map[string]string{DefaultUser: DefaultPassword},
+		sessions:    map[string]string{},
+		handlers:    map[string]HandlerFunc{},
+		custom:      map[string]bool{},
+		faults:      map[string][]error{},
+		itemFaults:  map[string]map[int]error{},
+		collections: map[string]*collection{},
+		calls:       map[string]int{},
+		uploads:     map[string]File{},
+		downloads:   map[string]File{},
+	}
+	for _, name := range []string{"Folders", "Mails", "Contacts", "Events", "Tasks", "Notes"} {
+		s.collections[name] = &collection{}
+	}
+	s.addDefaultFolders()
+	s.registerHandlers()
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPath, s.serveHTTP)
+	mux.HandleFunc(apiPath+"/upload/", s.serveUpload)
+	mux.HandleFunc(downloadPath, s.serveDownload)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Config returns the config for connecting to the server
+func (s *Server) Config(options ...webmail.Option) *webmail.Config {
+	host := strings.TrimPrefix(s.URL, "http://")
+	return webmail.NewConfig(host, append([]webmail.Option{webmail.WithScheme("http")}, options...)...)
+}
+
+// NewConnection returns a new connection to the server logged in as DefaultUser
+func (s *Server) NewConnection(options ...webmail.Option) (*webmail.ClientConnection, error) {
+	conn, err := s.Config(options...).NewConnection()
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.Login(DefaultUser, DefaultPassword, nil); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// AddUser adds the user which can log in
+func (s *Server) AddUser(userName, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[userName] = password
+}
+
+// Handle sets the handler of the method, replacing the built-in one if any.
+// The handler may call the methods of the server, e.g. AddMail or Mails.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+	s.custom[method] = true
+}
+
+// ExpireSessions invalidates all sessions, next calls fail with ErrorCodeSessionExpired
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]string{}
+}
+
+// FailNext makes the next call of method fail with err; empty method means any method.
+// Calling it several times queues the errors.
+func (s *Server) FailNext(method string, err *webmail.ApiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = append(s.faults[method], err)
+}
+
+// FailItems makes the next call of a method with list result (create, set, remove, copy, move, getById)
+// report err for the items with the given input indexes, the other items are processed
+func (s *Server) FailItems(method string, err *webmail.ApiError, inputIndexes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	faults := map[int]error{}
+	for _, index := range inputIndexes {
+		faults[index] = err
+	}
+	s.itemFaults[method] = faults
+}
+
+// SetMailQuota sets the maximum number of mails, creating more mails fails with ErrorCodeQuotaReached.
+// Zero means no limit.
+func (s *Server) SetMailQuota(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quota = limit
+}
+
+// Calls returns the number of calls of method received by server
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+type request struct {
+	JsonRpc string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JsonRpc string       `json:"jsonrpc"`
+	ID      int          `json:"id"`
+	Result  interface{}  `json:"result,omitempty"`
+	Error   *errorObject `json:"error,omitempty"`
+}
+
+type errorObject struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		MessageParameters webmail.LocalizableMessageParameters `json:"messageParameters"`
+	} `json:"data"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("X-Token")
+	var reply interface{}
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var requests []request
+		if err = json.Unmarshal(body, &requests); err != nil {
+			reply = errorResponse(0, &webmail.ApiError{Code: webmail.ErrorCodeParseError, Message: err.Error()})
+		} else {
+			responses := make([]response, len(requests))
+			for i, req := range requests {
+				responses[i] = s.call(token, req)
+			}
+			reply = responses
+		}
+	} else {
+		req := request{}
+		if err = json.Unmarshal(body, &req); err != nil {
+			reply = errorResponse(0, &webmail.ApiError{Code: webmail.ErrorCodeParseError, Message: err.Error()})
+		} else {
+			reply = s.call(token, req)
+		}
+	}
+	writeResponse(w, http.StatusOK, reply)
+}
+
+func writeResponse(w http.ResponseWriter, status int, reply interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(reply)
+}
+
+func errorResponse(id int, err error) response {
+	apiErr, ok := err.(*webmail.ApiError)
+	if !ok {
+		apiErr = &webmail.ApiError{Code: webmail.ErrorCodeInternalError, Message: err.Error()}
+	}
+	e := &errorObject{Code: apiErr.Code, Message: apiErr.Message}
+	e.Data.MessageParameters.PositionalParameters = apiErr.PositionalParameters
+	e.Data.MessageParameters.Plurality = apiErr.Plurality
+	return response{JsonRpc: "2.0", ID: id, Error: e}
+}
+
+// call dispatches one request to its handler, the built-in handlers are called with s.mu held
+func (s *Server) call(token string, req request) response {
+	s.mu.Lock()
+	locked := true
+	defer func() {
+		if locked {
+			s.mu.Unlock()
+		}
+	}()
+	s.calls[req.Method]++
+	for _, method := range []string{req.Method, ""} {
+		if faults := s.faults[method]; len(faults) > 0 {
+			s.faults[method] = faults[1:]
+			return errorResponse(req.ID, faults[0])
+		}
+	}
+	userName, ok := s.sessions[token]
+	if !ok && req.Method != "Session.login" {
+		return errorResponse(req.ID, webmail.ErrSessionExpired)
+	}
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		return errorResponse(req.ID, &webmail.ApiError{Code: webmail.ErrorCodeMethodNotFound, Message: "Method not found: " + req.Method})
+	}
+	if s.custom[req.Method] {
+		s.mu.Unlock()
+		locked = false
+	}
+	result, err := handler(userName, req.Params)
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+	return response{JsonRpc: "2.0", ID: req.ID, Result: result}
+}
+
+// newID returns a new unique identifier, the caller must hold s.mu
+func (s *Server) newID() webmail.KId {
+	s.lastID++
+	return webmail.KId(strconv.Itoa(s.lastID))
+}
+
+// nextWatermark returns a new item version, the caller must hold s.mu
+func (s *Server) nextWatermark() webmail.Watermark {
+	s.watermark++
+	return s.watermark
+}
+
+// takeItemFaults returns the item errors injected for method, the caller must hold s.mu
+func (s *Server) takeItemFaults(method string) map[int]error {
+	faults := s.itemFaults[method]
+	delete(s.itemFaults, method)
+	return faults
+}
+
+func itemError(index int, err error) webmail.Error {
+	apiErr, ok := err.(*webmail.ApiError)
+	if !ok {
+		apiErr = &webmail.ApiError{Code: webmail.ErrorCodeOperationFailed, Message: err.Error()}
+	}
+	return webmail.Error{
+		InputIndex: index,
+		Code:       apiErr.Code,
+		Message:    apiErr.Message,
+		MessageParameters: webmail.LocalizableMessageParameters{
+			PositionalParameters: apiErr.PositionalParameters,
+			Plurality:            apiErr.Plurality,
+		},
+	}
+}
+
+func invalidParams(err error) error {
+	return &webmail.ApiError{Code: webmail.ErrorCodeInvalidParams, Message: fmt.Sprintf("Invalid params: %v", err)}
+}
@@ -0,0 +1,502 @@
+package webmailtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/igiant/webmail"
+)
+
+func TestServer_Mails(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Invoice 1", ReceiveDate: "20260102T100000+0000"})
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Report", IsSeen: true, ReceiveDate: "20260101T100000+0000"})
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "invoice 2", ReceiveDate: "20260103T100000+0000"})
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := webmail.SearchQuery{
+		Conditions: webmail.SubConditionList{{FieldName: "subject", Comparator: webmail.Like, Value: "%invoice%"}},
+		OrderBy:    webmail.SortOrderList{{ColumnName: "receiveDate", Direction: webmail.Desc}},
+		Limit:      1,
+	}
+	list, total, err := conn.MailsGet(webmail.KIdList{InboxFolderId}, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(list) != 1 || list[0].Subject != "invoice 2" {
+		t.Errorf("unexpected result %d, %+v", total, list)
+	}
+	folders, err := conn.FoldersGet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		if folder.Id == InboxFolderId && (folder.MessageCount != 3 || folder.MessageUnread != 2) {
+			t.Errorf("unexpected counts %d, %d", folder.MessageCount, folder.MessageUnread)
+		}
+	}
+	errs, created, err := conn.MailsCreate(webmail.MailList{{Subject: "Hello", Send: true}})
+	if err != nil || len(errs) != 0 || len(created) != 1 {
+		t.Fatalf("unexpected result %v, %v, %v", errs, created, err)
+	}
+	if sent := server.Mails(SentItemsFolderId); len(sent) != 1 || sent[0].Id != created[0].Id {
+		t.Errorf("mail is not sent: %+v", sent)
+	}
+	errs, _, err = conn.MailsSet(webmail.MailList{{Id: created[0].Id, IsFlagged: true}, {Id: "missing"}})
+	if err != nil || len(errs) != 1 || errs[0].InputIndex != 1 || errs[0].Code != webmail.ErrorCodeNoSuchEntity {
+		t.Errorf("unexpected result %v, %v", errs, err)
+	}
+	if sent := server.Mails(SentItemsFolderId); !sent[0].IsFlagged || sent[0].Subject != "Hello" {
+		t.Errorf("mail is not updated: %+v", sent[0])
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.FailNext("Mails.get", webmail.ErrQuotaReached)
+	if _, _, err = conn.MailsGet(nil, webmail.SearchQuery{}); !errors.Is(err, webmail.ErrQuotaReached) {
+		t.Errorf("expected ErrQuotaReached, got %v", err)
+	}
+	server.FailItems("Contacts.create", webmail.ErrAlreadyExists, 1)
+	errs, created, err := conn.ContactsCreate(webmail.ContactList{
+		{FolderId: ContactsFolderId, CommonName: "Alice"},
+		{FolderId: ContactsFolderId, CommonName: "Bob"},
+	})
+	if err != nil || len(errs) != 1 || errs[0].InputIndex != 1 || len(created) != 1 || created[0].InputIndex != 0 {
+		t.Errorf("unexpected result %v, %v, %v", errs, created, err)
+	}
+	server.ExpireSessions()
+	if _, err = conn.SessionWhoAmI(); !errors.Is(err, webmail.ErrSessionExpired) {
+		t.Errorf("expected ErrSessionExpired, got %v", err)
+	}
+	conn.SetAutoRelogin(webmail.StaticCredentials(DefaultUser, DefaultPassword), nil)
+	user, err := conn.SessionWhoAmI()
+	if err != nil || user.LoginName != "user@example.com" {
+		t.Errorf("unexpected result %v, %v", user, err)
+	}
+	if server.Calls("Session.login") != 2 {
+		t.Errorf("expected 2 logins, got %d", server.Calls("Session.login"))
+	}
+	if _, err = conn.CallRaw("Unknown.method", nil); !errors.Is(err, webmail.ErrMethodNotFound) {
+		t.Errorf("expected ErrMethodNotFound, got %v", err)
+	}
+}
+
+func TestServer_Handle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Hello"})
+	server.Handle("Mails.get", func(userName string, params json.RawMessage) (interface{}, error) {
+		mails := server.Mails(InboxFolderId)
+		return map[string]interface{}{"list": mails, "totalItems": len(mails)}, nil
+	})
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list, total, err := conn.MailsGet(nil, webmail.SearchQuery{}); err != nil || total != 1 || list[0].Subject != "Hello" {
+		t.Errorf("unexpected result %v, %d, %v", list, total, err)
+	}
+	list, total, err := conn.ContactsGet(webmail.KIdList{ContactsFolderId}, webmail.SearchQuery{Start: -1})
+	if err != nil || total != 0 || len(list) != 0 {
+		t.Errorf("unexpected result %v, %d, %v", list, total, err)
+	}
+}
+
+func TestServer_MailsIterator(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	for i := 0; i < 5; i++ {
+		server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Mail"})
+	}
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := conn.MailsGetIterator(webmail.KIdList{InboxFolderId}, webmail.SearchQuery{}, webmail.WithPageSize(2), webmail.WithPrefetch())
+	count := 0
+	for it.Next() {
+		if it.Item().Subject != "Mail" {
+			t.Errorf("got %+v", it.Item())
+		}
+		count++
+	}
+	if it.Err() != nil || count != 5 {
+		t.Errorf("got %d mails, error %v", count, it.Err())
+	}
+}
+
+func TestServer_MailSearch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Q3 report", From: webmail.EMail{Address: "alice@example.com"}})
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Q3 report", From: webmail.EMail{Address: "bob@example.com"}, IsSeen: true})
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Invoice", From: webmail.EMail{Address: "alice@example.com"}})
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := webmail.MailSearch.Parse(`from:alice subject:"Q3 report" is:unread`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mails, total, err := conn.MailsGet(webmail.KIdList{InboxFolderId}, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || mails[0].From.Address != "alice@example.com" || mails[0].Subject != "Q3 report" {
+		t.Errorf("got %d mails: %+v", total, mails)
+	}
+}
+
+func TestServer_Upload(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	var records []webmail.CallRecord
+	conn, err := server.NewConnection(webmail.WithLogger(webmail.LoggerFunc(func(record webmail.CallRecord) {
+		records = append(records, record)
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachment, err := conn.UploadAttachment(strings.NewReader("hello"), `report "Q3".txt`, "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, ok := server.Upload(attachment.Id)
+	if !ok || string(file.Data) != "hello" || file.Name != `report "Q3".txt` || file.ContentType != "text/plain" {
+		t.Errorf("got %+v", file)
+	}
+	if attachment.Size != 5 || attachment.Name != file.Name {
+		t.Errorf("got %+v", attachment)
+	}
+	if last := records[len(records)-1]; last.Method != webmail.UploadMethod || last.RequestSize != 5 || last.Err != nil {
+		t.Errorf("got record %+v", last)
+	}
+
+	server.SetUploadLimit(3)
+	if _, err = conn.Upload(strings.NewReader("hello"), "big.bin", ""); !errors.Is(err, webmail.ErrRequestEntityTooLarge) {
+		t.Errorf("got %v, want ErrRequestEntityTooLarge", err)
+	}
+	server.ExpireSessions()
+	if _, err = conn.Upload(strings.NewReader("a"), "a.txt", ""); !errors.Is(err, webmail.ErrSessionExpired) {
+		t.Errorf("got %v, want ErrSessionExpired", err)
+	}
+	conn.SetAutoRelogin(webmail.StaticCredentials(DefaultUser, DefaultPassword), nil)
+	id, err := conn.Upload(strings.NewReader("abc"), "a.txt", "")
+	if file, _ := server.Upload(id); err != nil || string(file.Data) != "abc" {
+		t.Errorf("upload after re-login: %+v, %v", file, err)
+	}
+	server.ExpireSessions()
+	// not seekable, so it is not sent again
+	if _, err = conn.Upload(io.MultiReader(strings.NewReader("a")), "a.txt", ""); !errors.Is(err, webmail.ErrSessionExpired) {
+		t.Errorf("got %v, want ErrSessionExpired", err)
+	}
+	if _, err = conn.Upload(io.MultiReader(strings.NewReader("a")), "a.txt", ""); err != nil {
+		t.Errorf("session is not renewed: %v", err)
+	}
+}
+
+func TestServer_Download(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	download := server.AddFile("Q3 report.txt", "text/plain", []byte("hello"))
+	buffer := &bytes.Buffer{}
+	if err = conn.DownloadFile(buffer, download); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "hello" {
+		t.Errorf("got %q", buffer)
+	}
+	attachment := webmail.Attachment{Url: download.Url, Size: 6}
+	if err = conn.DownloadAttachment(ioutil.Discard, attachment); !errors.Is(err, webmail.ErrSizeMismatch) {
+		t.Errorf("got %v, want ErrSizeMismatch", err)
+	}
+	if _, err = conn.Download(ioutil.Discard, "/webmail/api/download/missing"); err == nil {
+		t.Error("download of missing file must fail")
+	}
+	server.ExpireSessions()
+	if err = conn.DownloadFile(ioutil.Discard, download); !errors.Is(err, webmail.ErrSessionExpired) {
+		t.Errorf("got %v, want ErrSessionExpired", err)
+	}
+	conn.SetAutoRelogin(webmail.StaticCredentials(DefaultUser, DefaultPassword), nil)
+	if err = conn.DownloadFile(ioutil.Discard, download); err != nil {
+		t.Errorf("download after re-login: %v", err)
+	}
+}
+
+func TestServer_Compose(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	original := webmail.Mail{
+		FolderId: InboxFolderId,
+		Subject:  "Q3 report",
+		From:     webmail.EMail{Name: "Alice", Address: "alice@example.com"},
+		To:       webmail.EMailList{{Address: "user@example.com"}, {Address: "Bob@example.com"}},
+		Cc:       webmail.EMailList{{Address: "bob@example.com"}, {Address: "carol@example.com"}},
+		IsSeen:   true,
+		Headers:  webmail.MimeHeaderList{{Type: webmail.MhMessageID, Value: "<1@example.com>"}},
+		DisplayableParts: webmail.DisplayableMimePartList{
+			{ContentType: webmail.CtTextPlain, Content: "Numbers\n> old"},
+		},
+		Attachments: webmail.AttachmentList{{Id: "a1", Name: "report.pdf", ContentType: "application/pdf"}},
+	}
+	original.Id = server.AddMail(original)
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := conn.ReplyAll(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Mail.To) != 2 || reply.Mail.To[1].Address != "Bob@example.com" ||
+		len(reply.Mail.Cc) != 1 || reply.Mail.Cc[0].Address != "carol@example.com" {
+		t.Errorf("recipients %+v, %+v", reply.Mail.To, reply.Mail.Cc)
+	}
+	reply.Text = "Thanks"
+	if err = reply.Send(); err != nil {
+		t.Fatal(err)
+	}
+	sent := server.Mails(SentItemsFolderId)
+	if len(sent) != 1 {
+		t.Fatalf("got %d sent mails", len(sent))
+	}
+	if sent[0].Subject != "Re: Q3 report" || len(sent[0].Headers) != 1 || sent[0].Headers[0].Type != webmail.MhInReplayTo {
+		t.Errorf("got %+v", sent[0])
+	}
+	if content := sent[0].DisplayableParts[0].Content; !strings.HasPrefix(content, "Thanks\n\nOn ") ||
+		!strings.HasSuffix(content, "Alice <alice@example.com> wrote:\n> Numbers\n>> old") {
+		t.Errorf("got %q", content)
+	}
+	if inbox := server.Mails(InboxFolderId); !inbox[0].IsAnswered || !inbox[0].IsSeen {
+		t.Errorf("original %+v", inbox[0])
+	}
+
+	forward := conn.Forward(original)
+	forward.Mail.To = webmail.EMailList{{Address: "dave@example.com"}}
+	id, err := forward.SaveDraft()
+	if err != nil {
+		t.Fatal(err)
+	}
+	drafts := server.Mails(DraftsFolderId)
+	if len(drafts) != 1 || drafts[0].Id != id || drafts[0].Subject != "Fwd: Q3 report" ||
+		len(drafts[0].Attachments) != 1 || drafts[0].Attachments[0].Id != "a1" {
+		t.Errorf("got %+v", drafts)
+	}
+	if inbox := server.Mails(InboxFolderId); inbox[0].IsForwarded {
+		t.Error("draft must not mark the original as forwarded")
+	}
+}
+
+func TestServer_EML(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := "From: Alice <alice@example.com>\r\n" +
+		"To: user@example.com\r\n" +
+		"Subject: Q3 report\r\n" +
+		"Date: Sat, 14 Mar 2020 09:26:53 +0000\r\n" +
+		"Message-ID: <1@example.com>\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=b1\r\n" +
+		"\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Numbers attached\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/csv; name=q3.csv\r\n" +
+		"Content-Disposition: attachment; filename=q3.csv\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"YSxiCjEsMgo=\r\n" +
+		"--b1--\r\n"
+	id, err := conn.ImportEML(strings.NewReader(message), InboxFolderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inbox := server.Mails(InboxFolderId)
+	if len(inbox) != 1 || inbox[0].Id != id || inbox[0].Subject != "Q3 report" || len(inbox[0].Attachments) != 1 {
+		t.Fatalf("got %+v", inbox)
+	}
+	attachment := inbox[0].Attachments[0]
+	if file, ok := server.Upload(attachment.Id); !ok || string(file.Data) != "a,b\n1,2\n" || attachment.Size != 8 {
+		t.Errorf("attachment %+v", attachment)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err = conn.ExportEML(buffer, inbox[0]); err != nil {
+		t.Fatal(err)
+	}
+	mail, attachments, err := webmail.ParseEML(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mail.Subject != "Q3 report" || mail.From.Address != "alice@example.com" || len(mail.DisplayableParts) != 1 ||
+		mail.DisplayableParts[0].Content != "Numbers attached" {
+		t.Errorf("got %+v", mail)
+	}
+	if len(attachments) != 1 || attachments[0].Attachment.Name != "q3.csv" || string(attachments[0].Data) != "a,b\n1,2\n" {
+		t.Errorf("attachments %+v", attachments)
+	}
+}
+
+func TestServer_ExportImportFolder(t *testing.T) {
+	for _, format := range []webmail.MailboxFormat{webmail.Mbox, webmail.Maildir} {
+		server := NewServer()
+		projects := server.AddFolder(webmail.Folder{ParentId: InboxFolderId, Name: "Projects/2020", Type: webmail.FMail})
+		// names of the same file if escaped naively or compared ignoring case, and Maildir subdirectory
+		similar := map[string]webmail.KId{}
+		for _, name := range []string{"Projects_2020", "projects/2020", "cur"} {
+			similar[name] = server.AddFolder(webmail.Folder{ParentId: InboxFolderId, Name: name, Type: webmail.FMail})
+			server.AddMail(webmail.Mail{FolderId: similar[name], Subject: name})
+		}
+		server.AddMail(webmail.Mail{
+			FolderId:         InboxFolderId,
+			Subject:          "Q3 report",
+			From:             webmail.EMail{Address: "alice@example.com"},
+			IsSeen:           true,
+			IsFlagged:        true,
+			DisplayableParts: webmail.DisplayableMimePartList{{ContentType: webmail.CtTextPlain, Content: "Numbers\nFrom the team"}},
+		})
+		server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Lunch", IsAnswered: true})
+		server.AddMail(webmail.Mail{FolderId: projects, Subject: "Kickoff"})
+		conn, err := server.NewConnection()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dir := t.TempDir()
+		if err = conn.ExportFolder(InboxFolderId, dir, format, true); err != nil {
+			t.Fatal(err)
+		}
+		path := dir + "/Inbox"
+		if format == webmail.Mbox {
+			path += ".mbox"
+		}
+		server.Close()
+
+		server = NewServer()
+		conn, err = server.NewConnection()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := conn.ImportFolder(path, RootFolderId, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != InboxFolderId {
+			t.Errorf("format %d: got folder %s, want existing Inbox", format, id)
+		}
+		inbox := server.Mails(InboxFolderId)
+		if len(inbox) != 2 {
+			t.Fatalf("format %d: got %d mails", format, len(inbox))
+		}
+		for _, mail := range inbox {
+			switch mail.Subject {
+			case "Q3 report":
+				if !mail.IsSeen || !mail.IsFlagged || mail.IsAnswered || mail.DisplayableParts[0].Content != "Numbers\nFrom the team" {
+					t.Errorf("format %d: got %+v", format, mail)
+				}
+			case "Lunch":
+				if mail.IsSeen || mail.IsFlagged || !mail.IsAnswered {
+					t.Errorf("format %d: got %+v", format, mail)
+				}
+			}
+		}
+		imported := map[string]webmail.KId{}
+		for _, folder := range server.Folders() {
+			if folder.ParentId == InboxFolderId {
+				imported[folder.Name] = folder.Id
+			}
+		}
+		if len(imported) != 4 {
+			t.Errorf("format %d: got subfolders %v", format, imported)
+		}
+		if mails := server.Mails(imported["Projects/2020"]); len(mails) != 1 || mails[0].Subject != "Kickoff" {
+			t.Errorf("format %d: subfolder mails %+v", format, mails)
+		}
+		for name := range similar {
+			if mails := server.Mails(imported[name]); len(mails) != 1 || mails[0].Subject != name {
+				t.Errorf("format %d: subfolder %s, mails %+v", format, name, mails)
+			}
+		}
+		server.Close()
+	}
+}
+
+func TestServer_Bulk(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	var ids webmail.KIdList
+	for i := 0; i < 7; i++ {
+		ids = append(ids, server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Ticket"}))
+	}
+	server.AddMail(webmail.Mail{FolderId: InboxFolderId, Subject: "Other"})
+	conn, err := server.NewConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := webmail.MailSearch.Parse("subject:Ticket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := conn.MailsGetIds(webmail.KIdList{InboxFolderId}, query)
+	if err != nil || len(found) != 7 {
+		t.Fatalf("got %v, %v", found, err)
+	}
+
+	result, err := conn.MailsBulkSet(found, map[webmail.MailField]interface{}{webmail.MailFieldIsSeen: true},
+		webmail.WithChunkSize(3), webmail.WithConcurrency(2))
+	if err != nil || result.Done != 7 || len(result.Errors) != 0 {
+		t.Fatalf("got %+v, %v", result, err)
+	}
+	for _, mail := range server.Mails(InboxFolderId) {
+		if mail.IsSeen != (mail.Subject == "Ticket") || mail.Subject == "" {
+			t.Errorf("got %+v", mail)
+		}
+	}
+
+	input := webmail.KIdList{ids[0], "missing1", ids[1], ids[2], "missing2", ids[3]}
+	result, err = conn.MailsBulkMove(input, JunkEmailFolderId, webmail.WithChunkSize(2), webmail.WithConcurrency(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Done != 6 || len(result.Errors) != 2 || result.Errors[0].InputIndex != 1 || result.Errors[1].InputIndex != 4 ||
+		!errors.Is(result.Errors[1].ApiError(), webmail.ErrNoSuchEntity) {
+		t.Errorf("errors %+v", result.Errors)
+	}
+	if len(result.Created) != 4 || result.Created[3].InputIndex != 5 || result.Created[3].Id != ids[3] {
+		t.Errorf("created %+v", result.Created)
+	}
+	if junk := server.Mails(JunkEmailFolderId); len(junk) != 4 {
+		t.Errorf("got %d moved mails", len(junk))
+	}
+
+	server.FailNext("Mails.remove", webmail.ErrAccessDenied)
+	result, err = conn.MailsBulkRemove(ids, webmail.WithChunkSize(2))
+	if !errors.Is(err, webmail.ErrAccessDenied) || result.Done != 0 || server.Calls("Mails.remove") != 1 {
+		t.Errorf("got %+v, %v after %d calls", result, err, server.Calls("Mails.remove"))
+	}
+}
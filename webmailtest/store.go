@@ -0,0 +1,322 @@
+package webmailtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/igiant/webmail"
+)
+
+// object - item of the store in its JSON form, so one implementation serves all item types
+type object map[string]interface{}
+
+type collection struct {
+	items []object
+}
+
+func toObject(v interface{}) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	o := object{}
+	err = json.Unmarshal(data, &o)
+	return o, err
+}
+
+func fromObject(o object, v interface{}) {
+	data, _ := json.Marshal(o)
+	_ = json.Unmarshal(data, v)
+}
+
+func (o object) id() webmail.KId {
+	id, _ := o["id"].(string)
+	return webmail.KId(id)
+}
+
+func (o object) folderId() webmail.KId {
+	id, _ := o["folderId"].(string)
+	return webmail.KId(id)
+}
+
+func (o object) clone() object {
+	c, _ := toObject(o)
+	return c
+}
+
+// merge copies the members of src to o. The client always sends all members,
+// so empty strings, zero numbers, empty lists and objects are skipped; booleans are always copied.
+func (o object) merge(src object) {
+	for key, value := range src {
+		if key == "id" || isZero(value) {
+			continue
+		}
+		o[key] = value
+	}
+}
+
+func isZero(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case float64:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		for _, item := range value {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *collection) find(id webmail.KId) object {
+	for _, item := range c.items {
+		if item.id() == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (c *collection) remove(id webmail.KId) bool {
+	for i, item := range c.items {
+		if item.id() == id {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// inFolders returns the items of the folders, all items if folderIds is empty
+func (c *collection) inFolders(folderIds webmail.KIdList) []object {
+	if len(folderIds) == 0 {
+		return c.items
+	}
+	var items []object
+	for _, item := range c.items {
+		for _, id := range folderIds {
+			if item.folderId() == id {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items
+}
+
+// search returns the page of items matching the query and the number of all matching items
+func search(items []object, query webmail.SearchQuery) ([]object, int) {
+	var found []object
+	for _, item := range items {
+		if matches(item, query) {
+			found = append(found, item)
+		}
+	}
+	for i := len(query.OrderBy) - 1; i >= 0; i-- {
+		order := query.OrderBy[i]
+		sort.SliceStable(found, func(a, b int) bool {
+			less := compareValues(lookup(found[a], order.ColumnName), lookup(found[b], order.ColumnName), order.CaseSensitive)
+			if order.Direction == webmail.Desc {
+				return less > 0
+			}
+			return less < 0
+		})
+	}
+	total := len(found)
+	start := query.Start
+	if start > total {
+		start = total
+	} else if start < 0 {
+		start = 0
+	}
+	found = found[start:]
+	if query.Limit >= 0 && query.Limit < len(found) {
+		found = found[:query.Limit]
+	}
+	page := make([]object, len(found))
+	for i, item := range found {
+		page[i] = project(item, query.Fields)
+	}
+	return page, total
+}
+
+// project returns the copy of item with the requested fields only
+func project(item object, fields webmail.StringList) object {
+	if len(fields) == 0 {
+		return item.clone()
+	}
+	o := object{"id": item["id"]}
+	for _, field := range fields {
+		if key, ok := findKey(item, field); ok {
+			o[key] = item[key]
+		}
+	}
+	return o.clone()
+}
+
+func matches(item object, query webmail.SearchQuery) bool {
+	if len(query.Conditions) == 0 {
+		return true
+	}
+	for _, condition := range query.Conditions {
+		ok := matchCondition(item, condition)
+		if query.Combining == webmail.And && !ok {
+			return false
+		}
+		if query.Combining != webmail.And && ok {
+			return true
+		}
+	}
+	return query.Combining == webmail.And
+}
+
+func matchCondition(item object, condition webmail.SubCondition) bool {
+	switch strings.ToUpper(condition.FieldName) {
+	case "QUICKSEARCH", "FULLTEXT":
+		return compare(strings.Join(texts(item), "\n"), webmail.Like, condition.Value)
+	}
+	return compare(textOf(lookup(item, condition.FieldName)), condition.Comparator, condition.Value)
+}
+
+// findKey returns the member of o matching name case-insensitively
+func findKey(o map[string]interface{}, name string) (string, bool) {
+	if _, ok := o[name]; ok {
+		return name, true
+	}
+	for key := range o {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// lookup returns the value of the field, nested members are separated by dots, e.g. "from.address"
+func lookup(item object, field string) interface{} {
+	var value interface{} = map[string]interface{}(item)
+	for _, name := range strings.Split(field, ".") {
+		o, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		key, ok := findKey(o, name)
+		if !ok {
+			return nil
+		}
+		value = o[key]
+	}
+	return value
+}
+
+// textOf returns the value as text, e-mail addresses as "name <address>"
+func textOf(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		list := make([]string, len(value))
+		for i, item := range value {
+			list[i] = textOf(item)
+		}
+		return strings.Join(list, ", ")
+	case map[string]interface{}:
+		if address, ok := value["address"].(string); ok {
+			name, _ := value["name"].(string)
+			return strings.TrimSpace(fmt.Sprintf("%s <%s>", name, address))
+		}
+		return strings.Join(texts(value), " ")
+	}
+	return fmt.Sprint(v)
+}
+
+// texts returns all strings of the value at any depth
+func texts(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var list []string
+		for _, item := range value {
+			list = append(list, texts(item)...)
+		}
+		return list
+	case map[string]interface{}:
+		var list []string
+		for _, item := range value {
+			list = append(list, texts(item)...)
+		}
+		return list
+	case object:
+		return texts(map[string]interface{}(value))
+	}
+	return nil
+}
+
+func compare(text string, operator webmail.CompareOperator, operand string) bool {
+	switch operator {
+	case webmail.Like:
+		return like(text, operand)
+	case webmail.NotEq:
+		return !strings.EqualFold(text, operand)
+	case webmail.LessThan:
+		return compareValues(text, operand, false) < 0
+	case webmail.GreaterThan:
+		return compareValues(text, operand, false) > 0
+	case webmail.LessEq:
+		return compareValues(text, operand, false) <= 0
+	case webmail.GreaterEq:
+		return compareValues(text, operand, false) >= 0
+	}
+	return strings.EqualFold(text, operand)
+}
+
+// like matches the pattern with % wildcards case-insensitively, a pattern without wildcards matches a substring
+func like(text, pattern string) bool {
+	if !strings.Contains(pattern, "%") {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(pattern))
+	}
+	parts := strings.Split(pattern, "%")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("(?is)^" + strings.Join(parts, ".*") + "$")
+	return err == nil && re.MatchString(text)
+}
+
+// compareValues compares numbers numerically and other values as text
+func compareValues(a, b interface{}, caseSensitive bool) int {
+	x, y := textOf(a), textOf(b)
+	if fx, err := strconv.ParseFloat(x, 64); err == nil {
+		if fy, err := strconv.ParseFloat(y, 64); err == nil {
+			switch {
+			case fx < fy:
+				return -1
+			case fx > fy:
+				return 1
+			}
+			return 0
+		}
+	}
+	if !caseSensitive {
+		x, y = strings.ToLower(x), strings.ToLower(y)
+	}
+	return strings.Compare(x, y)
+}